@@ -0,0 +1,12 @@
+data "schemaregistry_compatibility_check" "candidate" {
+  subject     = "example-value"
+  schema_type = "AVRO"
+  schema      = file("${path.module}/schemas/example-value.avsc")
+}
+
+check "schema_is_compatible" {
+  assert {
+    condition     = data.schemaregistry_compatibility_check.candidate.is_compatible
+    error_message = join("\n", data.schemaregistry_compatibility_check.candidate.messages)
+  }
+}
@@ -0,0 +1,5 @@
+data "schemaregistry_contexts" "all" {}
+
+output "contexts" {
+  value = data.schemaregistry_contexts.all.contexts
+}
@@ -0,0 +1,5 @@
+data "schemaregistry_global_config" "this" {}
+
+output "global_compatibility_level" {
+  value = data.schemaregistry_global_config.this.compatibility_level
+}
@@ -0,0 +1,7 @@
+data "schemaregistry_mode" "example" {
+  subject = "example-value"
+}
+
+output "mode" {
+  value = data.schemaregistry_mode.example.mode
+}
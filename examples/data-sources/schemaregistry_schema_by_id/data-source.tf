@@ -0,0 +1,7 @@
+data "schemaregistry_schema_by_id" "example" {
+  schema_id = 100042
+}
+
+output "subjects_using_schema" {
+  value = [for sv in data.schemaregistry_schema_by_id.example.subject_versions : "${sv.subject}@${sv.version}"]
+}
@@ -0,0 +1,8 @@
+data "schemaregistry_schema_referenced_by" "example" {
+  subject = "common-address"
+  version = 1
+}
+
+output "dependent_subjects" {
+  value = [for r in data.schemaregistry_schema_referenced_by.example.referenced_by : r.subject]
+}
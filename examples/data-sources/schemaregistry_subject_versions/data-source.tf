@@ -0,0 +1,8 @@
+data "schemaregistry_subject_versions" "example" {
+  subject         = "example-value"
+  include_deleted = true
+}
+
+output "schema_ids_by_version" {
+  value = { for v in data.schemaregistry_subject_versions.example.versions : v.version => v.schema_id }
+}
@@ -0,0 +1,9 @@
+data "schemaregistry_subjects" "orders" {
+  subject_prefix = "orders."
+  pattern        = "-value$"
+  include_latest = true
+}
+
+output "order_schema_ids" {
+  value = { for subject, latest in data.schemaregistry_subjects.orders.latest : subject => latest.schema_id }
+}
@@ -0,0 +1,4 @@
+resource "schemaregistry_global_config" "this" {
+  compatibility_level = "BACKWARD_TRANSITIVE"
+  normalize           = true
+}
@@ -0,0 +1,11 @@
+# Put a subject into IMPORT mode to register schemas under their original IDs
+resource "schemaregistry_mode" "migration" {
+  subject = "example-value"
+  mode    = "IMPORT"
+  force   = true
+}
+
+# Make the whole registry read-only
+resource "schemaregistry_mode" "global" {
+  mode = "READONLY"
+}
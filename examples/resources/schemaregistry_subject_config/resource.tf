@@ -0,0 +1,23 @@
+resource "schemaregistry_subject_config" "example" {
+  subject             = "example-value"
+  compatibility_level = "BACKWARD_TRANSITIVE"
+  normalize           = true
+
+  default_metadata = {
+    properties = {
+      owner = "team-example"
+    }
+  }
+
+  default_rule_set = {
+    domain_rules = [
+      {
+        name = "checkEmail"
+        kind = "CONDITION"
+        mode = "WRITE"
+        type = "CEL"
+        expr = "message.email.contains('@')"
+      },
+    ]
+  }
+}
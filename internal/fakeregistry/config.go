@@ -0,0 +1,205 @@
+package fakeregistry
+
+import (
+	"net/http"
+	"slices"
+)
+
+var (
+	compatibilityLevels = []string{
+		compatibilityNone, compatibilityBackward, compatibilityBackwardTransitive, compatibilityForward,
+		compatibilityForwardTransitive, compatibilityFull, compatibilityFullTransitive,
+	}
+	modes = []string{modeReadWrite, modeReadOnly, modeReadOnlyOverride, modeImport}
+)
+
+// configRequest is the body of PUT /config, which names the compatibility
+// level differently from the GET response. An alias or compatibility group
+// given as an empty string clears it.
+type configRequest struct {
+	config
+	Compatibility      string  `json:"compatibility,omitempty"`
+	Alias              *string `json:"alias,omitempty"`
+	CompatibilityGroup *string `json:"compatibilityGroup,omitempty"`
+}
+
+// routeConfig serves the global config at /config and subject configs at
+// /config/{subject}.
+func (r *Registry) routeConfig(req *http.Request, segments []string) (any, *registryError) {
+	query := req.URL.Query()
+	global := len(segments) == 0
+	name := ""
+	if !global {
+		name = segments[0]
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		if global {
+			return r.globalConfig, nil
+		}
+		if s, ok := r.subjects[name]; ok && s.config != nil {
+			return s.config, nil
+		}
+		if query.Get("defaultToGlobal") == "true" {
+			return r.globalConfig, nil
+		}
+		return nil, newError(ErrorCodeConfigNotFound, "Subject '%s' does not have subject-level compatibility "+
+			"configured", name)
+
+	case http.MethodPut:
+		var body configRequest
+		if err := decode(req, &body); err != nil {
+			return nil, err
+		}
+		if body.Compatibility != "" && !slices.Contains(compatibilityLevels, body.Compatibility) {
+			return nil, newError(ErrorCodeInvalidCompatibility, "Invalid compatibility level. Valid values are "+
+				"none, backward, forward, full, backward_transitive, forward_transitive, and full_transitive")
+		}
+
+		target := &r.globalConfig
+		if !global {
+			s := r.subject(name)
+			if s.config == nil {
+				s.config = &config{}
+			}
+			target = s.config
+		}
+		mergeConfig(target, body)
+
+		return map[string]any{"compatibility": target.CompatibilityLevel}, nil
+
+	case http.MethodDelete:
+		if global {
+			previous := r.globalConfig
+			r.globalConfig = config{CompatibilityLevel: compatibilityBackward}
+			return previous, nil
+		}
+		s, ok := r.subjects[name]
+		if !ok || s.config == nil {
+			return nil, newError(ErrorCodeSubjectNotFound, "Subject '%s' not found.", name)
+		}
+		previous := s.config
+		s.config = nil
+		return previous, nil
+	}
+
+	return nil, newError(405, "HTTP 405 Method Not Allowed")
+}
+
+// mergeConfig sets the fields of a config update that are present.
+func mergeConfig(target *config, update configRequest) {
+	if update.Compatibility != "" {
+		target.CompatibilityLevel = update.Compatibility
+	}
+	if update.Normalize != nil {
+		target.Normalize = update.Normalize
+	}
+	if update.Alias != nil {
+		target.Alias = *update.Alias
+	}
+	if update.CompatibilityGroup != nil {
+		target.CompatibilityGroup = *update.CompatibilityGroup
+	}
+	if update.DefaultMetadata != nil {
+		target.DefaultMetadata = update.DefaultMetadata
+	}
+	if update.OverrideMetadata != nil {
+		target.OverrideMetadata = update.OverrideMetadata
+	}
+	if update.DefaultRuleSet != nil {
+		target.DefaultRuleSet = update.DefaultRuleSet
+	}
+	if update.OverrideRuleSet != nil {
+		target.OverrideRuleSet = update.OverrideRuleSet
+	}
+}
+
+// modeBody is the request and response body of the mode endpoints.
+type modeBody struct {
+	Mode string `json:"mode"`
+}
+
+// routeMode serves the global mode at /mode and subject modes at
+// /mode/{subject}.
+func (r *Registry) routeMode(req *http.Request, segments []string) (any, *registryError) {
+	query := req.URL.Query()
+	global := len(segments) == 0
+	name := ""
+	if !global {
+		name = segments[0]
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		if global {
+			return modeBody{Mode: r.globalMode}, nil
+		}
+		if s, ok := r.subjects[name]; ok && s.mode != "" {
+			return modeBody{Mode: s.mode}, nil
+		}
+		if query.Get("defaultToGlobal") == "true" {
+			return modeBody{Mode: r.globalMode}, nil
+		}
+		return nil, newError(ErrorCodeModeNotFound, "Subject '%s' does not have subject-level mode configured",
+			name)
+
+	case http.MethodPut:
+		var body modeBody
+		if err := decode(req, &body); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(modes, body.Mode) {
+			return nil, newError(ErrorCodeInvalidMode, "Invalid mode. Valid values are READWRITE, READONLY, "+
+				"READONLY_OVERRIDE and IMPORT")
+		}
+		if body.Mode == modeImport && query.Get("force") != "true" && r.hasSchemas(name) {
+			return nil, newError(ErrorCodeOperationNotPermitted, "Cannot import since found existing subjects")
+		}
+
+		if global {
+			r.globalMode = body.Mode
+		} else {
+			r.subject(name).mode = body.Mode
+		}
+		return body, nil
+
+	case http.MethodDelete:
+		if global {
+			previous := r.globalMode
+			r.globalMode = modeReadWrite
+			return modeBody{Mode: previous}, nil
+		}
+		s, ok := r.subjects[name]
+		if !ok || s.mode == "" {
+			return nil, newError(ErrorCodeSubjectNotFound, "Subject '%s' not found.", name)
+		}
+		previous := s.mode
+		s.mode = ""
+		return modeBody{Mode: previous}, nil
+	}
+
+	return nil, newError(405, "HTTP 405 Method Not Allowed")
+}
+
+// subject returns a subject, creating it without versions when it does not
+// exist so that it can hold settings.
+func (r *Registry) subject(name string) *subject {
+	s, ok := r.subjects[name]
+	if !ok {
+		s = &subject{}
+		r.subjects[name] = s
+	}
+	return s
+}
+
+// hasSchemas reports whether a subject, or any subject when name is empty,
+// has live versions.
+func (r *Registry) hasSchemas(name string) bool {
+	for subjectName, s := range r.subjects {
+		if (name == "" || subjectName == name) && len(s.visibleVersions(false)) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,492 @@
+// Package fakeregistry provides an in-memory Confluent compatible Schema
+// Registry for tests that should not depend on Docker.
+//
+// It covers subjects, versions, global schema IDs, references, config, mode,
+// soft and hard deletes, lookups with normalization, compatibility checks and
+// the registry's error codes. Compatibility is only checked for Avro records,
+// by comparing their fields; other schemas are always compatible.
+package fakeregistry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Confluent Schema Registry error codes returned by the fake.
+const (
+	ErrorCodeSubjectNotFound       = 40401
+	ErrorCodeVersionNotFound       = 40402
+	ErrorCodeSchemaNotFound        = 40403
+	ErrorCodeSubjectSoftDeleted    = 40404
+	ErrorCodeSubjectNotSoftDeleted = 40405
+	ErrorCodeConfigNotFound        = 40408
+	ErrorCodeModeNotFound          = 40409
+	ErrorCodeIncompatibleSchema    = 409
+	ErrorCodeInvalidSchema         = 42201
+	ErrorCodeInvalidVersion        = 42202
+	ErrorCodeInvalidCompatibility  = 42203
+	ErrorCodeInvalidMode           = 42204
+	ErrorCodeOperationNotPermitted = 42205
+	ErrorCodeReferenceExists       = 42206
+	ErrorCodeInternalServerError   = 50001
+)
+
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+// Compatibility levels.
+const (
+	compatibilityNone               = "NONE"
+	compatibilityBackward           = "BACKWARD"
+	compatibilityBackwardTransitive = "BACKWARD_TRANSITIVE"
+	compatibilityForward            = "FORWARD"
+	compatibilityForwardTransitive  = "FORWARD_TRANSITIVE"
+	compatibilityFull               = "FULL"
+	compatibilityFullTransitive     = "FULL_TRANSITIVE"
+)
+
+// Modes.
+const (
+	modeReadWrite        = "READWRITE"
+	modeReadOnly         = "READONLY"
+	modeReadOnlyOverride = "READONLY_OVERRIDE"
+	modeImport           = "IMPORT"
+)
+
+// Registry is an in-memory Schema Registry served over HTTP.
+type Registry struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	subjects     map[string]*subject
+	schemas      map[int]*schemaEntry
+	nextID       int
+	globalConfig config
+	globalMode   string
+	failures     []*registryError
+}
+
+// reference is a reference from a schema to a version of another subject.
+type reference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// schemaEntry is a schema registered under a global ID.
+type schemaEntry struct {
+	id         int
+	schema     string
+	schemaType string
+	references []reference
+	metadata   json.RawMessage
+	ruleSet    json.RawMessage
+}
+
+// version is a version of a subject.
+type version struct {
+	version int
+	schema  *schemaEntry
+	deleted bool
+}
+
+// subject holds the versions and settings of a subject.
+type subject struct {
+	versions []*version
+	config   *config
+	mode     string
+}
+
+// config is a subject or global configuration.
+type config struct {
+	CompatibilityLevel string          `json:"compatibilityLevel,omitempty"`
+	Normalize          *bool           `json:"normalize,omitempty"`
+	Alias              string          `json:"alias,omitempty"`
+	CompatibilityGroup string          `json:"compatibilityGroup,omitempty"`
+	DefaultMetadata    json.RawMessage `json:"defaultMetadata,omitempty"`
+	OverrideMetadata   json.RawMessage `json:"overrideMetadata,omitempty"`
+	DefaultRuleSet     json.RawMessage `json:"defaultRuleSet,omitempty"`
+	OverrideRuleSet    json.RawMessage `json:"overrideRuleSet,omitempty"`
+}
+
+// registryError is an error response.
+type registryError struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+func (e *registryError) status() int {
+	if e.ErrorCode >= 10000 {
+		return e.ErrorCode / 100
+	}
+	return e.ErrorCode
+}
+
+func newError(code int, format string, args ...any) *registryError {
+	return &registryError{ErrorCode: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// New starts an empty registry in BACKWARD compatibility and READWRITE mode.
+// It is closed when the test finishes.
+func New(tb testing.TB) *Registry {
+	tb.Helper()
+
+	r := &Registry{
+		subjects:     map[string]*subject{},
+		schemas:      map[int]*schemaEntry{},
+		nextID:       1,
+		globalConfig: config{CompatibilityLevel: compatibilityBackward},
+		globalMode:   modeReadWrite,
+	}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	tb.Cleanup(r.Close)
+
+	return r
+}
+
+// FailNext makes the next request fail with the given registry error code,
+// e.g. to test how errors the fake would not produce on its own are handled.
+func (r *Registry) FailNext(errorCode int, message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failures = append(r.failures, &registryError{ErrorCode: errorCode, Message: message})
+}
+
+func (r *Registry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		out any
+		err *registryError
+	)
+	if len(r.failures) > 0 {
+		err, r.failures = r.failures[0], r.failures[1:]
+	} else {
+		out, err = r.route(req)
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if err != nil {
+		w.WriteHeader(err.status())
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// route dispatches a request to its handler by method and path.
+func (r *Registry) route(req *http.Request) (any, *registryError) {
+	segments, err := pathSegments(req)
+	if err != nil {
+		return nil, newError(404, "HTTP 404 Not Found")
+	}
+	query := req.URL.Query()
+	deleted := query.Get("deleted") == "true"
+	n := len(segments)
+
+	switch {
+	case n == 1 && segments[0] == "subjects" && req.Method == http.MethodGet:
+		return r.listSubjects(query.Get("subjectPrefix"), deleted), nil
+	case n == 2 && segments[0] == "subjects" && req.Method == http.MethodPost:
+		var body schemaRequest
+		if err := decode(req, &body); err != nil {
+			return nil, err
+		}
+		return r.lookupSchema(segments[1], body, query.Get("normalize") == "true", deleted)
+	case n == 2 && segments[0] == "subjects" && req.Method == http.MethodDelete:
+		return r.deleteSubject(segments[1], query.Get("permanent") == "true")
+	case n == 3 && segments[0] == "subjects" && segments[2] == "versions" && req.Method == http.MethodGet:
+		return r.listVersions(segments[1], deleted)
+	case n == 3 && segments[0] == "subjects" && segments[2] == "versions" && req.Method == http.MethodPost:
+		var body schemaRequest
+		if err := decode(req, &body); err != nil {
+			return nil, err
+		}
+		return r.registerSchema(segments[1], body, query.Get("normalize") == "true")
+	case n == 4 && segments[0] == "subjects" && segments[2] == "versions" && req.Method == http.MethodGet:
+		return r.getVersion(segments[1], segments[3], deleted)
+	case n == 4 && segments[0] == "subjects" && segments[2] == "versions" && req.Method == http.MethodDelete:
+		return r.deleteVersion(segments[1], segments[3], query.Get("permanent") == "true")
+	case n == 5 && segments[0] == "subjects" && segments[2] == "versions" && segments[4] == "referencedby" &&
+		req.Method == http.MethodGet:
+		return r.referencedBy(segments[1], segments[3])
+	case n == 3 && segments[0] == "schemas" && segments[1] == "ids" && req.Method == http.MethodGet:
+		return r.getSchemaByID(segments[2], query.Get("subject"))
+	case n == 4 && segments[0] == "schemas" && segments[1] == "ids" && segments[3] == "versions" &&
+		req.Method == http.MethodGet:
+		return r.getSchemaVersions(segments[2], query.Get("subject"), deleted)
+	case n == 2 && segments[0] == "schemas" && segments[1] == "types" && req.Method == http.MethodGet:
+		return []string{"AVRO", "JSON", "PROTOBUF"}, nil
+	case (n == 4 || n == 5) && segments[0] == "compatibility" && segments[1] == "subjects" &&
+		segments[3] == "versions" && req.Method == http.MethodPost:
+		var body schemaRequest
+		if err := decode(req, &body); err != nil {
+			return nil, err
+		}
+		versionID := ""
+		if n == 5 {
+			versionID = segments[4]
+		}
+		return r.checkCompatibility(segments[2], versionID, body, query.Get("normalize") == "true",
+			query.Get("verbose") == "true")
+	case (n == 1 || n == 2) && segments[0] == "config":
+		return r.routeConfig(req, segments[1:])
+	case (n == 1 || n == 2) && segments[0] == "mode":
+		return r.routeMode(req, segments[1:])
+	case n == 1 && segments[0] == "contexts" && req.Method == http.MethodGet:
+		return r.listContexts(), nil
+	case n == 0 && req.Method == http.MethodGet:
+		return map[string]any{}, nil
+	}
+
+	return nil, newError(404, "HTTP 404 Not Found")
+}
+
+// pathSegments splits the escaped request path into unescaped segments, so
+// that subjects containing escaped characters stay one segment.
+func pathSegments(req *http.Request) ([]string, error) {
+	path := strings.Trim(req.URL.EscapedPath(), "/")
+	if path == "" {
+		return nil, nil
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, err
+		}
+		segments[i] = unescaped
+	}
+	return segments, nil
+}
+
+func decode(req *http.Request, v any) *registryError {
+	if err := json.NewDecoder(req.Body).Decode(v); err != nil {
+		return newError(ErrorCodeInvalidSchema, "Invalid request body: %s", err)
+	}
+	return nil
+}
+
+// schemaRequest is the body of register, lookup and compatibility requests.
+type schemaRequest struct {
+	Schema     string          `json:"schema"`
+	SchemaType string          `json:"schemaType,omitempty"`
+	References []reference     `json:"references,omitempty"`
+	Metadata   json.RawMessage `json:"metadata,omitempty"`
+	RuleSet    json.RawMessage `json:"ruleSet,omitempty"`
+	ID         int             `json:"id,omitempty"`
+	Version    int             `json:"version,omitempty"`
+}
+
+// schemaResponse is a schema as returned by the registry. The schema type is
+// omitted for Avro.
+type schemaResponse struct {
+	Subject    string          `json:"subject,omitempty"`
+	Version    int             `json:"version,omitempty"`
+	ID         int             `json:"id,omitempty"`
+	Schema     string          `json:"schema"`
+	SchemaType string          `json:"schemaType,omitempty"`
+	References []reference     `json:"references,omitempty"`
+	Metadata   json.RawMessage `json:"metadata,omitempty"`
+	RuleSet    json.RawMessage `json:"ruleSet,omitempty"`
+}
+
+func newSchemaResponse(subjectName string, v *version) schemaResponse {
+	resp := schemaResponse{
+		Subject:    subjectName,
+		ID:         v.schema.id,
+		Schema:     v.schema.schema,
+		References: v.schema.references,
+		Metadata:   v.schema.metadata,
+		RuleSet:    v.schema.ruleSet,
+	}
+	if v.version != 0 {
+		resp.Version = v.version
+	}
+	if v.schema.schemaType != "AVRO" {
+		resp.SchemaType = v.schema.schemaType
+	}
+	return resp
+}
+
+// visibleVersions returns the versions of a subject, including soft-deleted
+// versions when deleted is set.
+func (s *subject) visibleVersions(deleted bool) []*version {
+	var versions []*version
+	for _, v := range s.versions {
+		if deleted || !v.deleted {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// findSubject returns a subject with visible versions, or a subject not found
+// error.
+func (r *Registry) findSubject(name string, deleted bool) (*subject, *registryError) {
+	s, ok := r.subjects[name]
+	if !ok || len(s.visibleVersions(deleted)) == 0 {
+		return nil, newError(ErrorCodeSubjectNotFound, "Subject '%s' not found.", name)
+	}
+	return s, nil
+}
+
+// findVersion resolves a version ID, which may be `latest` or -1, of a
+// subject.
+func (r *Registry) findVersion(name, versionID string, deleted bool) (*version, *registryError) {
+	s, err := r.findSubject(name, deleted)
+	if err != nil {
+		return nil, err
+	}
+	versions := s.visibleVersions(deleted)
+
+	if versionID == "latest" || versionID == "-1" {
+		return versions[len(versions)-1], nil
+	}
+	number, convErr := strconv.Atoi(versionID)
+	if convErr != nil || number <= 0 {
+		return nil, newError(ErrorCodeInvalidVersion, "The specified version '%s' is not a valid version id. "+
+			"Allowed values are between [1, 2^31-1] and the string \"latest\"", versionID)
+	}
+	for _, v := range versions {
+		if v.version == number {
+			return v, nil
+		}
+	}
+	return nil, newError(ErrorCodeVersionNotFound, "Version %d not found.", number)
+}
+
+func (r *Registry) listSubjects(prefix string, deleted bool) []string {
+	subjects := []string{}
+	for name, s := range r.subjects {
+		if strings.HasPrefix(name, prefix) && len(s.visibleVersions(deleted)) > 0 {
+			subjects = append(subjects, name)
+		}
+	}
+	sort.Strings(subjects)
+	return subjects
+}
+
+func (r *Registry) listVersions(name string, deleted bool) (any, *registryError) {
+	s, err := r.findSubject(name, deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []int{}
+	for _, v := range s.visibleVersions(deleted) {
+		versions = append(versions, v.version)
+	}
+	return versions, nil
+}
+
+func (r *Registry) getVersion(name, versionID string, deleted bool) (any, *registryError) {
+	v, err := r.findVersion(name, versionID, deleted)
+	if err != nil {
+		return nil, err
+	}
+	return newSchemaResponse(name, v), nil
+}
+
+// getSchemaByID returns a schema by ID. The fake assigns IDs across all
+// schema contexts, so an ID is only found in the context of subject when a
+// subject in that context uses it.
+func (r *Registry) getSchemaByID(idString, subject string) (any, *registryError) {
+	id, _ := strconv.Atoi(idString)
+	entry, ok := r.schemas[id]
+	if !ok || len(r.schemaVersions(id, subject, true)) == 0 {
+		return nil, newError(ErrorCodeSchemaNotFound, "Schema %s not found", idString)
+	}
+
+	resp := newSchemaResponse("", &version{schema: entry})
+	resp.ID = 0
+	return resp, nil
+}
+
+func (r *Registry) getSchemaVersions(idString, subject string, deleted bool) (any, *registryError) {
+	id, _ := strconv.Atoi(idString)
+	if _, ok := r.schemas[id]; !ok {
+		return nil, newError(ErrorCodeSchemaNotFound, "Schema %s not found", idString)
+	}
+	return r.schemaVersions(id, subject, deleted), nil
+}
+
+type subjectVersion struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// schemaVersions returns the subject versions that use a schema ID in the
+// schema context of subject.
+func (r *Registry) schemaVersions(id int, subject string, deleted bool) []subjectVersion {
+	schemaContext, _, _ := splitContext(subject)
+	versions := []subjectVersion{}
+	for _, name := range r.listSubjects("", deleted) {
+		if context, _, _ := splitContext(name); context != schemaContext {
+			continue
+		}
+		for _, v := range r.subjects[name].visibleVersions(deleted) {
+			if v.schema.id == id {
+				versions = append(versions, subjectVersion{Subject: name, Version: v.version})
+			}
+		}
+	}
+	return versions
+}
+
+func (r *Registry) referencedBy(name, versionID string) (any, *registryError) {
+	v, err := r.findVersion(name, versionID, false)
+	if err != nil {
+		return nil, err
+	}
+	return r.referencingIDs(name, v.version), nil
+}
+
+// referencingIDs returns the IDs of the schemas of live versions that
+// reference a version of a subject.
+func (r *Registry) referencingIDs(name string, number int) []int {
+	ids := []int{}
+	for _, s := range r.subjects {
+		for _, v := range s.visibleVersions(false) {
+			for _, ref := range v.schema.references {
+				if ref.Subject == name && ref.Version == number && !slices.Contains(ids, v.schema.id) {
+					ids = append(ids, v.schema.id)
+				}
+			}
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func (r *Registry) listContexts() []string {
+	contexts := []string{"."}
+	for name := range r.subjects {
+		if context, _, ok := splitContext(name); ok && !slices.Contains(contexts, context) {
+			contexts = append(contexts, context)
+		}
+	}
+	sort.Strings(contexts)
+	return contexts
+}
+
+// splitContext splits a subject qualified with a schema context, such as
+// `:.team-a:orders-value`, into the context and the unqualified subject.
+func splitContext(name string) (string, string, bool) {
+	if !strings.HasPrefix(name, ":.") {
+		return "", name, false
+	}
+	context, rest, ok := strings.Cut(name[1:], ":")
+	return context, rest, ok
+}
@@ -0,0 +1,315 @@
+package fakeregistry
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/riferrei/srclient"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+)
+
+const (
+	testSchemaV1 = `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`
+	testSchemaV2 = `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"},` +
+		`{"name":"total","type":"double","default":0}]}`
+	testSchemaIncompatible = `{"type":"record","name":"Order","fields":[{"name":"id","type":"long"}]}`
+)
+
+func testClient(t *testing.T) (*Registry, *utils.Client) {
+	t.Helper()
+
+	reg := New(t)
+	return reg, utils.NewClient(reg.URL, reg.Client())
+}
+
+func testRegister(t *testing.T, client *utils.Client, subject, schema string, references ...srclient.Reference) int {
+	t.Helper()
+
+	id, err := client.RegisterSchema(context.Background(), subject,
+		utils.NewSchemaRequest(schema, srclient.Avro, references), false)
+	if err != nil {
+		t.Fatalf("RegisterSchema(%s) error = %v", subject, err)
+	}
+	return id
+}
+
+func TestRegistry_registerAndLookup(t *testing.T) {
+	ctx := context.Background()
+	_, client := testClient(t)
+
+	id := testRegister(t, client, "orders-value", testSchemaV1)
+	if again := testRegister(t, client, "orders-value", testSchemaV1); again != id {
+		t.Errorf("registering the same schema again returned ID %d, want %d", again, id)
+	}
+	if other := testRegister(t, client, "returns-value", testSchemaV1); other != id {
+		t.Errorf("registering the same schema under another subject returned ID %d, want %d", other, id)
+	}
+	if v2 := testRegister(t, client, "orders-value", testSchemaV2); v2 == id {
+		t.Errorf("registering a new schema reused ID %d", id)
+	}
+
+	latest, err := client.GetSubjectVersion(ctx, "orders-value", "latest", false)
+	if err != nil {
+		t.Fatalf("GetSubjectVersion() error = %v", err)
+	}
+	if latest.Version != 2 || latest.Type() != "AVRO" {
+		t.Errorf("GetSubjectVersion() = version %d type %s, want version 2 type AVRO", latest.Version, latest.Type())
+	}
+
+	spaced := `{ "type": "record", "name": "Order", "fields": [ { "name": "id", "type": "string" } ] }`
+	found, err := client.LookupSchema(ctx, "orders-value", utils.NewSchemaRequest(spaced, srclient.Avro, nil), false)
+	if err != nil {
+		t.Fatalf("LookupSchema() error = %v", err)
+	}
+	if found.ID != id || found.Version != 1 {
+		t.Errorf("LookupSchema() = ID %d version %d, want ID %d version 1", found.ID, found.Version, id)
+	}
+
+	reordered := `{"name":"Order","type":"record","fields":[{"type":"string","name":"id"}]}`
+	req := utils.NewSchemaRequest(reordered, srclient.Avro, nil)
+	if _, err := client.LookupSchema(ctx, "orders-value", req, false); !errors.Is(err, utils.ErrSchemaNotFound) {
+		t.Errorf("LookupSchema() without normalize error = %v, want %v", err, utils.ErrSchemaNotFound)
+	}
+	if _, err := client.RegisterSchema(ctx, "normalized-value", utils.NewSchemaRequest(testSchemaV1, srclient.Avro,
+		nil), true); err != nil {
+		t.Fatalf("RegisterSchema() error = %v", err)
+	}
+	if _, err := client.LookupSchema(ctx, "normalized-value", req, true); err != nil {
+		t.Errorf("LookupSchema() with normalize error = %v", err)
+	}
+}
+
+func TestRegistry_errors(t *testing.T) {
+	ctx := context.Background()
+	_, client := testClient(t)
+	testRegister(t, client, "orders-value", testSchemaV1)
+
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{
+			name: "subject not found",
+			call: func() error {
+				_, err := client.GetSubjectVersion(ctx, "missing-value", "latest", false)
+				return err
+			},
+			wantErr: utils.ErrSubjectNotFound,
+		},
+		{
+			name: "version not found",
+			call: func() error {
+				_, err := client.GetSubjectVersion(ctx, "orders-value", "5", false)
+				return err
+			},
+			wantErr: utils.ErrVersionNotFound,
+		},
+		{
+			name: "schema not found",
+			call: func() error {
+				_, err := client.GetSchemaByID(ctx, 99, "")
+				return err
+			},
+			wantErr: utils.ErrSchemaNotFound,
+		},
+		{
+			name: "incompatible schema",
+			call: func() error {
+				_, err := client.RegisterSchema(ctx, "orders-value",
+					utils.NewSchemaRequest(testSchemaIncompatible, srclient.Avro, nil), false)
+				return err
+			},
+			wantErr: utils.ErrIncompatibleSchema,
+		},
+		{
+			name: "invalid schema",
+			call: func() error {
+				_, err := client.RegisterSchema(ctx, "orders-value",
+					utils.NewSchemaRequest(`{"type":"record"`, srclient.Avro, nil), false)
+				return err
+			},
+			wantErr: utils.ErrInvalidSchema,
+		},
+		{
+			name: "invalid reference",
+			call: func() error {
+				_, err := client.RegisterSchema(ctx, "invoices-value", utils.NewSchemaRequest(testSchemaV1,
+					srclient.Avro, []srclient.Reference{{Name: "Order", Subject: "missing-value", Version: 1}}), false)
+				return err
+			},
+			wantErr: utils.ErrInvalidSchema,
+		},
+		{
+			name: "config not found",
+			call: func() error {
+				_, err := client.GetConfig(ctx, "orders-value", false)
+				return err
+			},
+			wantErr: utils.ErrConfigNotFound,
+		},
+		{
+			name: "mode not found",
+			call: func() error {
+				_, err := client.GetMode(ctx, "orders-value", false)
+				return err
+			},
+			wantErr: utils.ErrModeNotFound,
+		},
+		{
+			name: "invalid compatibility level",
+			call: func() error {
+				return client.UpdateConfig(ctx, "orders-value", &utils.Config{CompatibilityLevel: "SIDEWAYS"})
+			},
+			wantErr: utils.ErrInvalidCompatibilityLevel,
+		},
+		{
+			name:    "invalid mode",
+			call:    func() error { return client.UpdateMode(ctx, "orders-value", "WRITEONLY", false) },
+			wantErr: utils.ErrInvalidMode,
+		},
+		{
+			name:    "import mode with existing schemas",
+			call:    func() error { return client.UpdateMode(ctx, "orders-value", utils.ModeImport, false) },
+			wantErr: utils.ErrOperationNotPermitted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistry_compatibility(t *testing.T) {
+	ctx := context.Background()
+	_, client := testClient(t)
+	testRegister(t, client, "orders-value", testSchemaV1)
+
+	result, err := client.CheckCompatibility(ctx, "orders-value", "latest",
+		utils.NewSchemaRequest(testSchemaIncompatible, srclient.Avro, nil), false)
+	if err != nil {
+		t.Fatalf("CheckCompatibility() error = %v", err)
+	}
+	if result.IsCompatible || len(result.Messages) == 0 {
+		t.Errorf("CheckCompatibility() = %+v, want incompatible with messages", result)
+	}
+
+	if err := client.UpdateConfig(ctx, "orders-value", &utils.Config{CompatibilityLevel: "NONE"}); err != nil {
+		t.Fatalf("UpdateConfig() error = %v", err)
+	}
+	config, err := client.GetConfig(ctx, "orders-value", false)
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if config.CompatibilityLevel != "NONE" {
+		t.Errorf("GetConfig() compatibility = %s, want NONE", config.CompatibilityLevel)
+	}
+	testRegister(t, client, "orders-value", testSchemaIncompatible)
+
+	if err := client.DeleteConfig(ctx, "orders-value"); err != nil {
+		t.Fatalf("DeleteConfig() error = %v", err)
+	}
+	config, err = client.GetConfig(ctx, "orders-value", true)
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if config.CompatibilityLevel != "BACKWARD" {
+		t.Errorf("GetConfig() with defaultToGlobal compatibility = %s, want BACKWARD", config.CompatibilityLevel)
+	}
+}
+
+func TestRegistry_delete(t *testing.T) {
+	ctx := context.Background()
+	_, client := testClient(t)
+	testRegister(t, client, "customer-value", testSchemaV1)
+	testRegister(t, client, "orders-value", testSchemaV2,
+		srclient.Reference{Name: "Customer", Subject: "customer-value", Version: 1})
+
+	if err := client.DeleteSubject(ctx, "customer-value", false); !errors.Is(err, utils.ErrReferenceExists) {
+		t.Errorf("DeleteSubject() of a referenced subject error = %v, want %v", err, utils.ErrReferenceExists)
+	}
+	ids, err := client.GetReferencedBy(ctx, "customer-value", "1")
+	if err != nil || len(ids) != 1 {
+		t.Errorf("GetReferencedBy() = %v, %v, want one ID", ids, err)
+	}
+
+	if err := client.DeleteSubject(ctx, "orders-value", false); err != nil {
+		t.Fatalf("DeleteSubject() error = %v", err)
+	}
+	if _, err := client.ListSubjectVersions(ctx, "orders-value", false); !errors.Is(err, utils.ErrSubjectNotFound) {
+		t.Errorf("ListSubjectVersions() of a soft-deleted subject error = %v, want %v", err, utils.ErrSubjectNotFound)
+	}
+	if subjects, err := client.ListSubjects(ctx, "orders-value", true); err != nil ||
+		!slices.Contains(subjects, "orders-value") {
+		t.Errorf("ListSubjects() with deleted = %v, %v, want orders-value listed", subjects, err)
+	}
+	if err := client.DeleteSubject(ctx, "orders-value", false); err == nil {
+		t.Error("DeleteSubject() of a soft-deleted subject succeeded, want an error")
+	}
+
+	if err := client.DeleteSubject(ctx, "customer-value", true); err != nil {
+		t.Fatalf("DeleteSubject() permanent error = %v", err)
+	}
+	subjects, err := client.ListSubjects(ctx, "", true)
+	if err != nil {
+		t.Fatalf("ListSubjects() error = %v", err)
+	}
+	if len(subjects) != 1 || subjects[0] != "orders-value" {
+		t.Errorf("ListSubjects() with deleted = %v, want [orders-value]", subjects)
+	}
+}
+
+func TestRegistry_import(t *testing.T) {
+	ctx := context.Background()
+	_, client := testClient(t)
+
+	req := utils.NewSchemaRequest(testSchemaV1, srclient.Avro, nil)
+	req.ID, req.Version = 100, 3
+	if _, err := client.RegisterSchema(ctx, "orders-value", req, false); !errors.Is(err, utils.ErrOperationNotPermitted) {
+		t.Errorf("RegisterSchema() with an ID outside import mode error = %v, want %v", err,
+			utils.ErrOperationNotPermitted)
+	}
+
+	if err := client.UpdateMode(ctx, "orders-value", utils.ModeImport, false); err != nil {
+		t.Fatalf("UpdateMode() error = %v", err)
+	}
+	id, err := client.RegisterSchema(ctx, "orders-value", req, false)
+	if err != nil {
+		t.Fatalf("RegisterSchema() in import mode error = %v", err)
+	}
+	schema, err := client.GetSubjectVersion(ctx, "orders-value", "3", false)
+	if err != nil {
+		t.Fatalf("GetSubjectVersion() error = %v", err)
+	}
+	if id != 100 || schema.ID != 100 {
+		t.Errorf("imported schema ID = %d, %d, want 100", id, schema.ID)
+	}
+
+	if err := client.UpdateMode(ctx, "orders-value", utils.ModeReadOnly, false); err != nil {
+		t.Fatalf("UpdateMode() error = %v", err)
+	}
+	_, err = client.RegisterSchema(ctx, "orders-value", utils.NewSchemaRequest(testSchemaV2, srclient.Avro, nil), false)
+	if !errors.Is(err, utils.ErrOperationNotPermitted) {
+		t.Errorf("RegisterSchema() in read-only mode error = %v, want %v", err, utils.ErrOperationNotPermitted)
+	}
+}
+
+func TestRegistry_FailNext(t *testing.T) {
+	ctx := context.Background()
+	reg, client := testClient(t)
+
+	reg.FailNext(ErrorCodeInternalServerError, "Error in the backend datastore")
+	if _, err := client.ListSubjects(ctx, "", false); !errors.Is(err, utils.ErrServerError) {
+		t.Errorf("ListSubjects() error = %v, want %v", err, utils.ErrServerError)
+	}
+	if _, err := client.ListSubjects(ctx, "", false); err != nil {
+		t.Errorf("ListSubjects() after the failure error = %v", err)
+	}
+}
@@ -0,0 +1,476 @@
+package fakeregistry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// canonicalSchema returns the form in which a schema is stored and compared.
+// Avro and JSON schemas are compacted, and normalization also orders their
+// keys; Protobuf schemas are trimmed, and normalization also collapses their
+// whitespace.
+func canonicalSchema(schemaType, schema string, normalize bool) (string, *registryError) {
+	if schemaType == "PROTOBUF" {
+		if strings.TrimSpace(schema) == "" {
+			return "", newError(ErrorCodeInvalidSchema, "Invalid schema: empty Protobuf schema")
+		}
+		if normalize {
+			return strings.Join(strings.Fields(schema), " "), nil
+		}
+		return strings.TrimSpace(schema), nil
+	}
+
+	var parsed any
+	if err := json.Unmarshal([]byte(schema), &parsed); err != nil {
+		return "", newError(ErrorCodeInvalidSchema, "Invalid schema: %s", err)
+	}
+	if schemaType == "AVRO" {
+		if err := validateAvro(parsed); err != nil {
+			return "", newError(ErrorCodeInvalidSchema, "Invalid schema: %s", err)
+		}
+	}
+
+	if normalize {
+		normalized, err := json.Marshal(parsed)
+		if err != nil {
+			return "", newError(ErrorCodeInvalidSchema, "Invalid schema: %s", err)
+		}
+		return string(normalized), nil
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(schema)); err != nil {
+		return "", newError(ErrorCodeInvalidSchema, "Invalid schema: %s", err)
+	}
+	return compact.String(), nil
+}
+
+// validateAvro checks the parts of an Avro schema the fake relies on: named
+// types have a name and records have a list of named fields.
+func validateAvro(schema any) error {
+	object, ok := schema.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	switch object["type"] {
+	case "record", "error", "enum", "fixed":
+		if name, _ := object["name"].(string); name == "" {
+			return fmt.Errorf("%s has no name", object["type"])
+		}
+	}
+	if object["type"] != "record" && object["type"] != "error" {
+		return nil
+	}
+
+	fields, ok := object["fields"].([]any)
+	if !ok {
+		return fmt.Errorf("record %s has no fields", object["name"])
+	}
+	for _, field := range fields {
+		f, _ := field.(map[string]any)
+		if name, _ := f["name"].(string); name == "" {
+			return fmt.Errorf("record %s has a field without a name", object["name"])
+		}
+		if _, ok := f["type"]; !ok {
+			return fmt.Errorf("field %s of record %s has no type", f["name"], object["name"])
+		}
+		if err := validateAvro(f["type"]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemaType returns the schema type of a request, which defaults to Avro.
+func (req schemaRequest) schemaType() string {
+	if req.SchemaType == "" {
+		return "AVRO"
+	}
+	return req.SchemaType
+}
+
+// normalizes reports whether schemas registered under a subject are
+// normalized, either because the request asks for it or the subject or
+// global config enables it.
+func (r *Registry) normalizes(name string, normalize bool) bool {
+	if normalize {
+		return true
+	}
+	if s, ok := r.subjects[name]; ok && s.config != nil && s.config.Normalize != nil {
+		return *s.config.Normalize
+	}
+	return r.globalConfig.Normalize != nil && *r.globalConfig.Normalize
+}
+
+// matches reports whether a stored schema is the schema of a request. The
+// data contract is only compared when the request has one.
+func matches(entry *schemaEntry, schemaType, schema string, req schemaRequest) bool {
+	if entry.schemaType != schemaType || entry.schema != schema ||
+		!jsonEqual(mustMarshal(entry.references), mustMarshal(req.References)) {
+		return false
+	}
+	if req.Metadata != nil && !jsonEqual(entry.metadata, req.Metadata) {
+		return false
+	}
+	return req.RuleSet == nil || jsonEqual(entry.ruleSet, req.RuleSet)
+}
+
+// jsonEqual reports whether two JSON documents are equal, treating null and
+// empty documents as equal.
+func jsonEqual(a, b json.RawMessage) bool {
+	var x, y any
+	_ = json.Unmarshal(a, &x)
+	_ = json.Unmarshal(b, &y)
+	return string(mustMarshal(x)) == string(mustMarshal(y))
+}
+
+func mustMarshal(v any) json.RawMessage {
+	if refs, ok := v.([]reference); ok && len(refs) == 0 {
+		return nil
+	}
+	b, _ := json.Marshal(v)
+	return b
+}
+
+// mode returns the mode of a subject, falling back to the global mode.
+func (r *Registry) mode(name string) string {
+	if s, ok := r.subjects[name]; ok && s.mode != "" {
+		return s.mode
+	}
+	return r.globalMode
+}
+
+// compatibilityLevel returns the compatibility level of a subject, falling
+// back to the global level.
+func (r *Registry) compatibilityLevel(name string) string {
+	if s, ok := r.subjects[name]; ok && s.config != nil && s.config.CompatibilityLevel != "" {
+		return s.config.CompatibilityLevel
+	}
+	return r.globalConfig.CompatibilityLevel
+}
+
+// checkReferences checks that every reference resolves to a live version.
+func (r *Registry) checkReferences(references []reference) *registryError {
+	for _, ref := range references {
+		if _, err := r.findVersion(ref.Subject, fmt.Sprint(ref.Version), false); err != nil {
+			return newError(ErrorCodeInvalidSchema, "Invalid schema: reference %s to version %d of subject %s "+
+				"does not exist", ref.Name, ref.Version, ref.Subject)
+		}
+	}
+	return nil
+}
+
+func (r *Registry) registerSchema(name string, req schemaRequest, normalize bool) (any, *registryError) {
+	mode := r.mode(name)
+	if mode == modeReadOnly || mode == modeReadOnlyOverride {
+		return nil, newError(ErrorCodeOperationNotPermitted, "Subject %s is in read-only mode", name)
+	}
+	if (req.ID != 0 || req.Version != 0) && mode != modeImport {
+		return nil, newError(ErrorCodeOperationNotPermitted, "Subject %s is not in import mode", name)
+	}
+
+	schemaType := req.schemaType()
+	schema, err := canonicalSchema(schemaType, req.Schema, r.normalizes(name, normalize))
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkReferences(req.References); err != nil {
+		return nil, err
+	}
+
+	s, ok := r.subjects[name]
+	if !ok {
+		s = &subject{}
+		r.subjects[name] = s
+	}
+	live := s.visibleVersions(false)
+
+	// Registering a schema the subject already has returns its ID
+	for _, v := range live {
+		if matches(v.schema, schemaType, schema, req) && (req.Version == 0 || req.Version == v.version) {
+			return map[string]int{"id": v.schema.id}, nil
+		}
+	}
+
+	if mode != modeImport {
+		if messages := r.incompatibilities(name, schemaType, schema, ""); len(messages) > 0 {
+			return nil, newError(ErrorCodeIncompatibleSchema, "Schema being registered is incompatible with an "+
+				"earlier schema for subject \"%s\", details: [%s]", name, strings.Join(messages, ", "))
+		}
+	}
+
+	// Like the registry, a new version without a data contract carries over
+	// the data contract of the latest version
+	if len(live) > 0 {
+		latest := live[len(live)-1].schema
+		if req.Metadata == nil {
+			req.Metadata = latest.metadata
+		}
+		if req.RuleSet == nil {
+			req.RuleSet = latest.ruleSet
+		}
+	}
+
+	entry, err := r.schemaEntry(schemaType, schema, req)
+	if err != nil {
+		return nil, err
+	}
+
+	number := req.Version
+	if number == 0 {
+		number = 1
+		if len(s.versions) > 0 {
+			number = s.versions[len(s.versions)-1].version + 1
+		}
+	}
+	for _, v := range s.versions {
+		if v.version == number {
+			return nil, newError(ErrorCodeOperationNotPermitted, "Version %d of subject %s already exists with "+
+				"a different schema", number, name)
+		}
+	}
+
+	s.versions = append(s.versions, &version{version: number, schema: entry})
+	return map[string]int{"id": entry.id}, nil
+}
+
+// schemaEntry returns the global entry of a schema, reusing the ID of an
+// identical schema registered under any subject, or the ID of an import.
+func (r *Registry) schemaEntry(schemaType, schema string, req schemaRequest) (*schemaEntry, *registryError) {
+	if req.ID != 0 {
+		if entry, ok := r.schemas[req.ID]; ok {
+			if !matches(entry, schemaType, schema, req) {
+				return nil, newError(ErrorCodeOperationNotPermitted, "Overwrite new schema with id %d is not "+
+					"permitted.", req.ID)
+			}
+			return entry, nil
+		}
+	} else {
+		for id := 1; id < r.nextID; id++ {
+			entry, ok := r.schemas[id]
+			if ok && matches(entry, schemaType, schema, req) && jsonEqual(entry.metadata, req.Metadata) &&
+				jsonEqual(entry.ruleSet, req.RuleSet) {
+				return entry, nil
+			}
+		}
+	}
+
+	id := req.ID
+	if id == 0 {
+		id = r.nextID
+	}
+	r.nextID = max(r.nextID, id+1)
+
+	entry := &schemaEntry{
+		id:         id,
+		schema:     schema,
+		schemaType: schemaType,
+		references: req.References,
+		metadata:   req.Metadata,
+		ruleSet:    req.RuleSet,
+	}
+	r.schemas[id] = entry
+	return entry, nil
+}
+
+func (r *Registry) lookupSchema(name string, req schemaRequest, normalize, deleted bool) (any, *registryError) {
+	s, err := r.findSubject(name, deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaType := req.schemaType()
+	schema, err := canonicalSchema(schemaType, req.Schema, r.normalizes(name, normalize))
+	if err != nil {
+		return nil, err
+	}
+
+	versions := s.visibleVersions(deleted)
+	for i := len(versions) - 1; i >= 0; i-- {
+		if matches(versions[i].schema, schemaType, schema, req) {
+			return newSchemaResponse(name, versions[i]), nil
+		}
+	}
+	return nil, newError(ErrorCodeSchemaNotFound, "Schema not found")
+}
+
+func (r *Registry) deleteSubject(name string, permanent bool) (any, *registryError) {
+	s, ok := r.subjects[name]
+	if !ok || len(s.versions) == 0 {
+		return nil, newError(ErrorCodeSubjectNotFound, "Subject '%s' not found.", name)
+	}
+
+	versions := []int{}
+	for _, v := range s.versions {
+		versions = append(versions, v.version)
+	}
+
+	if permanent {
+		if len(s.visibleVersions(false)) > 0 {
+			return nil, newError(ErrorCodeSubjectNotSoftDeleted, "Subject '%s' was not deleted first before "+
+				"being permanently deleted", name)
+		}
+		s.versions = nil
+		return versions, nil
+	}
+
+	if len(s.visibleVersions(false)) == 0 {
+		return nil, newError(ErrorCodeSubjectSoftDeleted, "Subject '%s' was soft deleted.Set permanent=true "+
+			"to delete permanently", name)
+	}
+	for _, v := range s.versions {
+		if ids := r.referencingIDs(name, v.version); len(ids) > 0 {
+			return nil, newError(ErrorCodeReferenceExists, "One or more references exist to the schema "+
+				"{subject=%s,version=%d}.", name, v.version)
+		}
+	}
+	for _, v := range s.versions {
+		v.deleted = true
+	}
+	return versions, nil
+}
+
+func (r *Registry) deleteVersion(name, versionID string, permanent bool) (any, *registryError) {
+	v, err := r.findVersion(name, versionID, permanent)
+	if err != nil {
+		return nil, err
+	}
+	s := r.subjects[name]
+
+	if permanent {
+		if !v.deleted {
+			return nil, newError(ErrorCodeSubjectNotSoftDeleted, "Subject '%s' Version %d was not deleted "+
+				"first before being permanently deleted", name, v.version)
+		}
+		s.versions = slices.DeleteFunc(s.versions, func(existing *version) bool { return existing == v })
+		return v.version, nil
+	}
+
+	if ids := r.referencingIDs(name, v.version); len(ids) > 0 {
+		return nil, newError(ErrorCodeReferenceExists, "One or more references exist to the schema "+
+			"{subject=%s,version=%d}.", name, v.version)
+	}
+	v.deleted = true
+	return v.version, nil
+}
+
+func (r *Registry) checkCompatibility(name, versionID string, req schemaRequest, normalize,
+	verbose bool) (any, *registryError) {
+	schemaType := req.schemaType()
+	schema, err := canonicalSchema(schemaType, req.Schema, r.normalizes(name, normalize))
+	if err != nil {
+		return nil, err
+	}
+
+	// Like the registry, checking against all versions requires the subject
+	if versionID != "" {
+		if _, err := r.findVersion(name, versionID, false); err != nil {
+			return nil, err
+		}
+	} else if _, err := r.findSubject(name, false); err != nil {
+		return nil, err
+	}
+
+	messages := r.incompatibilities(name, schemaType, schema, versionID)
+	result := map[string]any{"is_compatible": len(messages) == 0}
+	if verbose {
+		result["messages"] = append([]string{}, messages...)
+	}
+	return result, nil
+}
+
+// incompatibilities returns why a schema is incompatible with the versions of
+// a subject its compatibility level applies to, or with versionID when it is
+// set.
+func (r *Registry) incompatibilities(name, schemaType, schema, versionID string) []string {
+	s, ok := r.subjects[name]
+	if !ok {
+		return nil
+	}
+	live := s.visibleVersions(false)
+	if len(live) == 0 {
+		return nil
+	}
+
+	level := r.compatibilityLevel(name)
+	var against []*version
+	switch {
+	case level == compatibilityNone:
+		return nil
+	case versionID != "":
+		v, _ := r.findVersion(name, versionID, false)
+		against = []*version{v}
+	case strings.HasSuffix(level, "_TRANSITIVE"):
+		against = live
+	default:
+		against = live[len(live)-1:]
+	}
+
+	var messages []string
+	for _, v := range against {
+		if v.schema.schemaType != schemaType {
+			messages = append(messages, fmt.Sprintf("schema type changed from %s to %s at version %d",
+				v.schema.schemaType, schemaType, v.version))
+			continue
+		}
+		if schemaType != "AVRO" {
+			continue
+		}
+		if level != compatibilityForward && level != compatibilityForwardTransitive {
+			messages = append(messages, avroIncompatibilities(schema, v.schema.schema, v.version)...)
+		}
+		if level != compatibilityBackward && level != compatibilityBackwardTransitive {
+			messages = append(messages, avroIncompatibilities(v.schema.schema, schema, v.version)...)
+		}
+	}
+	return messages
+}
+
+// avroIncompatibilities returns why data written with the writer schema
+// cannot be read with the reader schema. Only record fields are compared: a
+// reader field must exist in the writer or have a default, and fields in both
+// must have the same type.
+func avroIncompatibilities(reader, writer string, writerVersion int) []string {
+	var readerSchema, writerSchema any
+	_ = json.Unmarshal([]byte(reader), &readerSchema)
+	_ = json.Unmarshal([]byte(writer), &writerSchema)
+
+	readerRecord, readerOK := readerSchema.(map[string]any)
+	writerRecord, writerOK := writerSchema.(map[string]any)
+	if !readerOK || !writerOK || readerRecord["type"] != "record" || writerRecord["type"] != "record" {
+		if string(mustMarshal(readerSchema)) != string(mustMarshal(writerSchema)) {
+			return []string{fmt.Sprintf("TYPE_MISMATCH: reader type does not match the writer type at version %d",
+				writerVersion)}
+		}
+		return nil
+	}
+
+	writerFields := map[string]any{}
+	for _, field := range writerRecord["fields"].([]any) {
+		f := field.(map[string]any)
+		writerFields[f["name"].(string)] = f["type"]
+	}
+
+	var messages []string
+	for _, field := range readerRecord["fields"].([]any) {
+		f := field.(map[string]any)
+		fieldName := f["name"].(string)
+		writerType, ok := writerFields[fieldName]
+		if !ok {
+			if _, hasDefault := f["default"]; !hasDefault {
+				messages = append(messages, fmt.Sprintf("READER_FIELD_MISSING_DEFAULT_VALUE: %s at version %d",
+					fieldName, writerVersion))
+			}
+			continue
+		}
+		if string(mustMarshal(writerType)) != string(mustMarshal(f["type"])) {
+			messages = append(messages, fmt.Sprintf("TYPE_MISMATCH: field %s at version %d", fieldName,
+				writerVersion))
+		}
+	}
+	return messages
+}
@@ -0,0 +1,505 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/riferrei/srclient"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/fakeregistry"
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+)
+
+// Registry flavours the conformance suite knows about.
+const (
+	flavourFake      = "fake"
+	flavourConfluent = "confluent"
+	flavourKarapace  = "karapace"
+	flavourApicurio  = "apicurio"
+	flavourRedpanda  = "redpanda"
+)
+
+// conformanceKnownGaps records, per flavour, the behaviours that are known
+// not to hold, and why. A known gap that fails is skipped, and one that
+// passes fails the suite so that the record is kept current. Only gaps seen in
+// a run against the flavour are recorded; no flavour other than the fake has
+// been run yet, so any failure on them is a gap still to be recorded here.
+var conformanceKnownGaps = map[string]map[string]string{}
+
+const (
+	conformanceCustomerSchema = `{"type":"record","name":"Customer","fields":[{"name":"id","type":"string"}]}`
+	conformanceOrderSchema    = `{"type":"record","name":"Order","fields":[{"name":"customer","type":"Customer"}]}`
+)
+
+// conformanceTarget is a registry the conformance suite runs against.
+type conformanceTarget struct {
+	flavour    string
+	url        string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// conformanceTargets returns the in-memory registry, and the registry at
+// SCHEMA_REGISTRY_CONFORMANCE_URL or, during acceptance tests with
+// SCHEMA_REGISTRY_CONFORMANCE_REDPANDA set, the Redpanda container. Redpanda
+// is opt-in until the suite has been run against it and its gaps recorded.
+func conformanceTargets(t *testing.T) []conformanceTarget {
+	t.Helper()
+
+	reg := fakeregistry.New(t)
+	targets := []conformanceTarget{{flavour: flavourFake, url: reg.URL, httpClient: reg.Client()}}
+
+	switch {
+	case os.Getenv("SCHEMA_REGISTRY_CONFORMANCE_URL") != "":
+		flavour := os.Getenv("SCHEMA_REGISTRY_CONFORMANCE_FLAVOR")
+		known := []string{flavourConfluent, flavourKarapace, flavourApicurio, flavourRedpanda}
+		if !slices.Contains(known, flavour) {
+			t.Fatalf("SCHEMA_REGISTRY_CONFORMANCE_FLAVOR must be one of %s, got %q", strings.Join(known, ", "),
+				flavour)
+		}
+		targets = append(targets, conformanceTarget{
+			flavour:    flavour,
+			url:        os.Getenv("SCHEMA_REGISTRY_CONFORMANCE_URL"),
+			username:   os.Getenv("SCHEMA_REGISTRY_CONFORMANCE_USERNAME"),
+			password:   os.Getenv("SCHEMA_REGISTRY_CONFORMANCE_PASSWORD"),
+			httpClient: &http.Client{Timeout: defaultTimeout},
+		})
+	case os.Getenv("TF_ACC") != "" && os.Getenv("SCHEMA_REGISTRY_CONFORMANCE_REDPANDA") != "":
+		targets = append(targets, conformanceTarget{
+			flavour:    flavourRedpanda,
+			url:        os.Getenv("SCHEMA_REGISTRY_URL"),
+			username:   os.Getenv("SCHEMA_REGISTRY_USERNAME"),
+			password:   os.Getenv("SCHEMA_REGISTRY_PASSWORD"),
+			httpClient: &http.Client{Timeout: defaultTimeout},
+		})
+	}
+
+	return targets
+}
+
+func (target conformanceTarget) client() *utils.Client {
+	client := utils.NewClient(target.url, target.httpClient)
+	client.SetCredentials(target.username, target.password)
+	client.SetRetryDelays([]time.Duration{100 * time.Millisecond, 500 * time.Millisecond, time.Second})
+	return client
+}
+
+// purge soft and then permanently deletes a subject, ignoring errors, so that
+// runs against a shared registry leave nothing behind.
+func (target conformanceTarget) purge(subject string) {
+	for _, query := range []string{"", "?permanent=true"} {
+		req, err := http.NewRequest(http.MethodDelete, target.url+"/subjects/"+url.PathEscape(subject)+query, nil)
+		if err != nil {
+			return
+		}
+		if target.username != "" {
+			req.SetBasicAuth(target.username, target.password)
+		}
+		if resp, err := target.httpClient.Do(req); err == nil {
+			_ = resp.Body.Close()
+		}
+	}
+}
+
+// conformanceEnv is what a conformance case runs with.
+type conformanceEnv struct {
+	t      *testing.T
+	target conformanceTarget
+	client *utils.Client
+	r      *schemaResource
+	s      schema.Schema
+	prefix string
+}
+
+// subject returns a subject unique to the run, which is purged when the case
+// finishes.
+func (e *conformanceEnv) subject(name string) string {
+	subject := e.prefix + "-" + name
+	e.t.Cleanup(func() { e.target.purge(subject) })
+	return subject
+}
+
+// create creates a schema resource and returns its state model.
+func (e *conformanceEnv) create(m schemaResourceModel) (schemaResourceModel, error) {
+	resp := testUnitCreate(e.r, e.s, testUnitPlan(e.t, e.s, m))
+	if err := diagsError("Create", resp.Diagnostics); err != nil {
+		return schemaResourceModel{}, err
+	}
+	return testUnitModel(e.t, resp.State), nil
+}
+
+// update updates a schema resource from state to the planned model.
+func (e *conformanceEnv) update(plan, state schemaResourceModel) (schemaResourceModel, error) {
+	plan.ID = state.ID
+	resp := testUnitUpdate(e.r, e.s, testUnitPlan(e.t, e.s, plan), testUnitState(e.t, e.s, state))
+	if err := diagsError("Update", resp.Diagnostics); err != nil {
+		return schemaResourceModel{}, err
+	}
+	return testUnitModel(e.t, resp.State), nil
+}
+
+// read refreshes a schema resource, and reports whether it is still present.
+func (e *conformanceEnv) read(state schemaResourceModel) (schemaResourceModel, bool, error) {
+	resp := testUnitRead(e.r, testUnitState(e.t, e.s, state))
+	if err := diagsError("Read", resp.Diagnostics); err != nil {
+		return schemaResourceModel{}, false, err
+	}
+	if resp.State.Raw.IsNull() {
+		return schemaResourceModel{}, false, nil
+	}
+	return testUnitModel(e.t, resp.State), true, nil
+}
+
+func (e *conformanceEnv) delete(state schemaResourceModel) error {
+	return diagsError("Delete", testUnitDelete(e.r, testUnitState(e.t, e.s, state)).Diagnostics)
+}
+
+// diagsError returns the first error of diags as an error.
+func diagsError(operation string, diags diag.Diagnostics) error {
+	if !diags.HasError() {
+		return nil
+	}
+	first := diags.Errors()[0]
+	return fmt.Errorf("%s: %s: %s", operation, first.Summary(), first.Detail())
+}
+
+// conformanceCase is a provider behaviour checked against every target.
+type conformanceCase struct {
+	behaviour string
+	run       func(ctx context.Context, e *conformanceEnv) error
+}
+
+var conformanceCases = []conformanceCase{
+	{behaviour: "semantic-no-diff", run: conformanceSemanticNoDiff},
+	{behaviour: "references", run: conformanceReferences},
+	{behaviour: "soft-delete", run: conformanceSoftDelete},
+	{behaviour: "hard-delete", run: conformanceHardDelete},
+	{behaviour: "import", run: conformanceImport},
+	{behaviour: "compatibility-change", run: conformanceCompatibilityChange},
+	{behaviour: "error-codes", run: conformanceErrorCodes},
+}
+
+func TestConformance(t *testing.T) {
+	prefix := "conformance-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	for _, target := range conformanceTargets(t) {
+		t.Run(target.flavour, func(t *testing.T) {
+			for _, tc := range conformanceCases {
+				t.Run(tc.behaviour, func(t *testing.T) {
+					client := target.client()
+					r := &schemaResource{client: client, compatibilityCheck: compatibilityCheckError}
+					var schemaResp resource.SchemaResponse
+					r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+					e := &conformanceEnv{
+						t:      t,
+						target: target,
+						client: client,
+						r:      r,
+						s:      schemaResp.Schema,
+						prefix: prefix + "-" + tc.behaviour,
+					}
+					err := tc.run(context.Background(), e)
+
+					reason, known := conformanceKnownGaps[target.flavour][tc.behaviour]
+					switch {
+					case known && err != nil:
+						t.Skipf("known gap on %s: %s: %v", target.flavour, reason, err)
+					case known:
+						t.Errorf("%s now holds on %s, remove it from conformanceKnownGaps", tc.behaviour,
+							target.flavour)
+					case err != nil:
+						t.Error(err)
+					}
+				})
+			}
+		})
+	}
+}
+
+// conformanceSemanticNoDiff checks that a schema differing from the
+// registered one only in formatting is not registered again.
+func conformanceSemanticNoDiff(_ context.Context, e *conformanceEnv) error {
+	subject := e.subject("orders-value")
+	spaced := `{
+  "type": "record",
+  "name": "Customer",
+  "fields": [{ "name": "id", "type": "string" }]
+}`
+
+	created, err := e.create(testUnitSchemaModel(subject, spaced))
+	if err != nil {
+		return err
+	}
+	if !utils.SchemasEqual(created.SchemaType.ValueString(), created.Schema.ValueString(), spaced) {
+		return fmt.Errorf("registered schema %s is not semantically equal to the configured schema", created.Schema)
+	}
+
+	updated, err := e.update(testUnitSchemaModel(subject, conformanceCustomerSchema), created)
+	if err != nil {
+		return err
+	}
+	if updated.Version.ValueInt64() != created.Version.ValueInt64() {
+		return fmt.Errorf("reformatting the schema registered version %d", updated.Version.ValueInt64())
+	}
+	return nil
+}
+
+// conformanceReferences checks that references round-trip and that a
+// referenced subject cannot be deleted.
+func conformanceReferences(ctx context.Context, e *conformanceEnv) error {
+	customerSubject := e.subject("customer-value")
+	orderSubject := e.subject("order-value")
+
+	customer, err := e.create(testUnitSchemaModel(customerSubject, conformanceCustomerSchema))
+	if err != nil {
+		return err
+	}
+
+	m := testUnitSchemaModel(orderSubject, conformanceOrderSchema)
+	m.Reference = utils.FromRegistryReferences([]srclient.Reference{
+		{Name: "Customer", Subject: customerSubject, Version: 1},
+	})
+	order, err := e.create(m)
+	if err != nil {
+		return err
+	}
+	if !order.Reference.Equal(m.Reference) {
+		return fmt.Errorf("references = %s, want %s", order.Reference, m.Reference)
+	}
+
+	ids, err := e.client.GetReferencedBy(ctx, customerSubject, "1")
+	if err != nil {
+		return fmt.Errorf("GetReferencedBy: %w", err)
+	}
+	if !slices.Contains(ids, int(order.SchemaID.ValueInt64())) {
+		return fmt.Errorf("referenced by %v, want schema %d", ids, order.SchemaID.ValueInt64())
+	}
+
+	if err := e.delete(customer); err == nil || !strings.Contains(err.Error(), "42206") {
+		return fmt.Errorf("deleting a referenced subject returned %v, want error code 42206", err)
+	}
+	return nil
+}
+
+// conformanceSoftDelete checks that a soft-deleted subject is removed from
+// state and can be created again.
+func conformanceSoftDelete(ctx context.Context, e *conformanceEnv) error {
+	subject := e.subject("orders-value")
+
+	created, err := e.create(testUnitSchemaModel(subject, conformanceCustomerSchema))
+	if err != nil {
+		return err
+	}
+	if err := e.delete(created); err != nil {
+		return err
+	}
+
+	softDeleted, err := testSubjectSoftDeleted(ctx, e.client, subject)
+	if err != nil {
+		return err
+	}
+	if !softDeleted {
+		return errors.New("subject is not listed as soft deleted")
+	}
+	if _, present, err := e.read(created); err != nil || present {
+		return fmt.Errorf("reading a soft-deleted subject returned present = %t, %v, want it removed", present, err)
+	}
+
+	_, err = e.create(testUnitSchemaModel(subject, conformanceCustomerSchema))
+	return err
+}
+
+// conformanceHardDelete checks that hard_delete removes every trace of the
+// subject.
+func conformanceHardDelete(ctx context.Context, e *conformanceEnv) error {
+	subject := e.subject("orders-value")
+
+	m := testUnitSchemaModel(subject, conformanceCustomerSchema)
+	m.HardDelete = types.BoolValue(true)
+	created, err := e.create(m)
+	if err != nil {
+		return err
+	}
+	if err := e.delete(created); err != nil {
+		return err
+	}
+
+	subjects, err := e.client.ListSubjects(ctx, subject, true)
+	if err != nil {
+		return fmt.Errorf("ListSubjects: %w", err)
+	}
+	if slices.Contains(subjects, subject) {
+		return errors.New("subject is still listed with deleted subjects")
+	}
+	return nil
+}
+
+// conformanceImport checks that importing a subject registered outside of
+// Terraform captures its latest version and compatibility level, and that
+// refreshing the imported state changes nothing.
+func conformanceImport(ctx context.Context, e *conformanceEnv) error {
+	subject := e.subject("orders-value")
+
+	for _, schemaString := range []string{testUnitSchemaV1, testUnitSchemaV2} {
+		req := utils.NewSchemaRequest(schemaString, srclient.Avro, nil)
+		if _, err := e.client.RegisterSchema(ctx, subject, req, false); err != nil {
+			return fmt.Errorf("RegisterSchema: %w", err)
+		}
+	}
+	if err := e.client.UpdateConfig(ctx, subject, &utils.Config{CompatibilityLevel: "FULL"}); err != nil {
+		return fmt.Errorf("UpdateConfig: %w", err)
+	}
+
+	resp := resource.ImportStateResponse{State: testUnitEmptyState(e.s)}
+	e.r.ImportState(ctx, resource.ImportStateRequest{ID: subject}, &resp)
+	if err := diagsError("ImportState", resp.Diagnostics); err != nil {
+		return err
+	}
+	imported := testUnitModel(e.t, resp.State)
+	if imported.Version.ValueInt64() != 2 || imported.CompatibilityLevel.ValueString() != "FULL" {
+		return fmt.Errorf("imported version %d compatibility %s, want version 2 compatibility FULL",
+			imported.Version.ValueInt64(), imported.CompatibilityLevel.ValueString())
+	}
+
+	read, present, err := e.read(imported)
+	if err != nil || !present {
+		return fmt.Errorf("reading the imported subject returned present = %t, %v", present, err)
+	}
+	if read.SchemaID != imported.SchemaID || !read.Schema.Equal(imported.Schema) {
+		return fmt.Errorf("refreshing the imported state changed schema_id %d to %d", imported.SchemaID.ValueInt64(),
+			read.SchemaID.ValueInt64())
+	}
+	return nil
+}
+
+// conformanceCompatibilityChange checks that an incompatible schema is
+// rejected, and accepted once compatibility_level is relaxed.
+func conformanceCompatibilityChange(ctx context.Context, e *conformanceEnv) error {
+	subject := e.subject("orders-value")
+
+	m := testUnitSchemaModel(subject, testUnitSchemaV1)
+	m.CompatibilityLevel = types.StringValue("BACKWARD")
+	state, err := e.create(m)
+	if err != nil {
+		return err
+	}
+
+	incompatible := testUnitSchemaModel(subject, testUnitSchemaIncompatible)
+	incompatible.CompatibilityLevel = types.StringValue("BACKWARD")
+	if _, err := e.update(incompatible, state); err == nil || !strings.Contains(err.Error(), "error code 409") {
+		return fmt.Errorf("registering an incompatible schema returned %v, want error code 409", err)
+	}
+
+	m.CompatibilityLevel = types.StringValue("NONE")
+	if state, err = e.update(m, state); err != nil {
+		return err
+	}
+	config, err := e.client.GetConfig(ctx, subject, false)
+	if err != nil {
+		return fmt.Errorf("GetConfig: %w", err)
+	}
+	if config.CompatibilityLevel != "NONE" {
+		return fmt.Errorf("subject compatibility level %s, want NONE", config.CompatibilityLevel)
+	}
+
+	incompatible.CompatibilityLevel = types.StringValue("NONE")
+	_, err = e.update(incompatible, state)
+	return err
+}
+
+// conformanceErrorCodes checks that the registry reports failures with the
+// error codes the provider classifies.
+func conformanceErrorCodes(ctx context.Context, e *conformanceEnv) error {
+	subject := e.subject("orders-value")
+	avro := func(schemaString string) utils.SchemaRequest {
+		return utils.NewSchemaRequest(schemaString, srclient.Avro, nil)
+	}
+	if _, err := e.client.RegisterSchema(ctx, subject, avro(testUnitSchemaV1), false); err != nil {
+		return fmt.Errorf("RegisterSchema: %w", err)
+	}
+
+	checks := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{
+			name: "missing subject",
+			call: func() error {
+				_, err := e.client.GetSubjectVersion(ctx, subject+"-missing", "latest", false)
+				return err
+			},
+			wantErr: utils.ErrSubjectNotFound,
+		},
+		{
+			name: "missing version",
+			call: func() error {
+				_, err := e.client.GetSubjectVersion(ctx, subject, "99", false)
+				return err
+			},
+			wantErr: utils.ErrVersionNotFound,
+		},
+		{
+			name: "missing schema ID",
+			call: func() error {
+				_, err := e.client.GetSchemaByID(ctx, 999999999, "")
+				return err
+			},
+			wantErr: utils.ErrSchemaNotFound,
+		},
+		{
+			name: "invalid schema",
+			call: func() error {
+				_, err := e.client.RegisterSchema(ctx, subject, avro(`{"type":"record","name":"Order"}`), false)
+				return err
+			},
+			wantErr: utils.ErrInvalidSchema,
+		},
+		{
+			name: "incompatible schema",
+			call: func() error {
+				_, err := e.client.RegisterSchema(ctx, subject, avro(testUnitSchemaIncompatible), false)
+				return err
+			},
+			wantErr: utils.ErrIncompatibleSchema,
+		},
+		{
+			name: "missing subject config",
+			call: func() error {
+				_, err := e.client.GetConfig(ctx, subject, false)
+				return err
+			},
+			wantErr: utils.ErrConfigNotFound,
+		},
+		{
+			name: "invalid compatibility level",
+			call: func() error {
+				return e.client.UpdateConfig(ctx, subject, &utils.Config{CompatibilityLevel: "SIDEWAYS"})
+			},
+			wantErr: utils.ErrInvalidCompatibilityLevel,
+		},
+	}
+
+	var errs []error
+	for _, check := range checks {
+		if err := check.call(); !errors.Is(err, check.wantErr) {
+			errs = append(errs, fmt.Errorf("%s returned %v, want %v", check.name, err, check.wantErr))
+		}
+	}
+	return errors.Join(errs...)
+}
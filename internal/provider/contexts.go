@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// contextDescription describes the `context` attribute of resources and data
+// sources that address a subject.
+const contextDescription = "The schema context of the subject, e.g. `.team-a`. Subjects and references that are " +
+	"already qualified with a context, e.g. `:.team-b:orders-value`, are used as given. Defaults to the " +
+	"provider `context`."
+
+// effectiveContext returns the configured schema context, or defaultContext
+// when none is configured.
+func effectiveContext(configured types.String, defaultContext string) string {
+	if configured.IsNull() || configured.IsUnknown() {
+		return defaultContext
+	}
+	return configured.ValueString()
+}
+
+// qualifySubject qualifies subject with the configured schema context, or
+// with defaultContext when none is configured.
+func qualifySubject(configured types.String, defaultContext, subject string) string {
+	return utils.QualifySubject(effectiveContext(configured, defaultContext), subject)
+}
+
+// splitImportID splits the ID of an imported subject, which may be qualified
+// with a schema context, into the subject and context attributes. The context
+// is left null when it is defaultContext, so that it keeps following the
+// provider setting.
+func splitImportID(id, defaultContext string) (types.String, types.String) {
+	schemaContext, subject := utils.SplitSubject(id)
+	if schemaContext == "" || utils.QualifySubject(schemaContext, "") == utils.QualifySubject(defaultContext, "") {
+		return types.StringValue(subject), types.StringNull()
+	}
+	return types.StringValue(subject), types.StringValue(schemaContext)
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/riferrei/srclient"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+)
+
+// testUnitSubjectVersion is an element of a list of subject versions.
+type testUnitSubjectVersion struct {
+	Subject types.String `tfsdk:"subject"`
+	Version types.Int64  `tfsdk:"version"`
+}
+
+func TestDataSources_unitContext(t *testing.T) {
+	ctx := context.Background()
+	_, client := testUnitClient(t)
+
+	register := func(subject, schema string, references ...srclient.Reference) int {
+		t.Helper()
+		id, err := client.RegisterSchema(ctx, subject, utils.NewSchemaRequest(schema, srclient.Avro,
+			references), false)
+		if err != nil {
+			t.Fatalf("RegisterSchema(%s) error = %v", subject, err)
+		}
+		return id
+	}
+	customerID := register(":.team-a:customer-value", testUnitSchemaV1)
+	register(":.team-a:orders-value", testUnitSchemaV2,
+		srclient.Reference{Name: "Customer", Subject: ":.team-a:customer-value", Version: 1})
+	register("orders-value", testUnitSchemaIncompatible)
+
+	teamA := tftypes.NewValue(tftypes.String, ".team-a")
+	unscoped := &providerData{client: client}
+	scoped := &providerData{client: client, schemaContext: ".team-a"}
+
+	t.Run("subjects", func(t *testing.T) {
+		for name, data := range map[string]*providerData{"attribute": unscoped, "provider": scoped} {
+			attrs := map[string]tftypes.Value{}
+			if data == unscoped {
+				attrs["context"] = teamA
+			}
+			resp := testUnitDataSourceRead(t, &subjectsDataSource{}, data, attrs)
+			testUnitNoErrors(t, "Read "+name, resp.Diagnostics)
+
+			var subjects []string
+			testUnitNoErrors(t, "reading subjects", resp.State.GetAttribute(ctx, path.Root("subjects"), &subjects))
+			if !slices.Equal(subjects, []string{"customer-value", "orders-value"}) {
+				t.Errorf("%s: subjects = %v, want the unqualified subjects of .team-a", name, subjects)
+			}
+		}
+	})
+
+	t.Run("schema_by_id", func(t *testing.T) {
+		id := tftypes.NewValue(tftypes.Number, customerID)
+		for name, data := range map[string]*providerData{"attribute": unscoped, "provider": scoped} {
+			attrs := map[string]tftypes.Value{"schema_id": id}
+			if data == unscoped {
+				attrs["context"] = teamA
+			}
+			resp := testUnitDataSourceRead(t, &schemaByIDDataSource{}, data, attrs)
+			testUnitNoErrors(t, "Read "+name, resp.Diagnostics)
+
+			var versions []testUnitSubjectVersion
+			testUnitNoErrors(t, "reading subject_versions",
+				resp.State.GetAttribute(ctx, path.Root("subject_versions"), &versions))
+			if len(versions) != 1 || versions[0].Subject.ValueString() != "customer-value" {
+				t.Errorf("%s: subject_versions = %v, want customer-value", name, versions)
+			}
+		}
+
+		resp := testUnitDataSourceRead(t, &schemaByIDDataSource{}, unscoped, map[string]tftypes.Value{"schema_id": id})
+		if !resp.Diagnostics.HasError() {
+			t.Error("Read of an ID from another context succeeded, want an error")
+		}
+	})
+
+	t.Run("subject_versions", func(t *testing.T) {
+		resp := testUnitDataSourceRead(t, &subjectVersionsDataSource{}, unscoped, map[string]tftypes.Value{
+			"subject": tftypes.NewValue(tftypes.String, "customer-value"),
+			"context": teamA,
+		})
+		testUnitNoErrors(t, "Read", resp.Diagnostics)
+
+		var id string
+		testUnitNoErrors(t, "reading id", resp.State.GetAttribute(ctx, path.Root("id"), &id))
+		if id != ":.team-a:customer-value" {
+			t.Errorf("id = %s, want :.team-a:customer-value", id)
+		}
+	})
+
+	t.Run("schema_referenced_by", func(t *testing.T) {
+		resp := testUnitDataSourceRead(t, &schemaReferencedByDataSource{}, unscoped, map[string]tftypes.Value{
+			"subject": tftypes.NewValue(tftypes.String, "customer-value"),
+			"context": teamA,
+		})
+		testUnitNoErrors(t, "Read", resp.Diagnostics)
+
+		var referencedBy []testUnitSubjectVersion
+		testUnitNoErrors(t, "reading referenced_by",
+			resp.State.GetAttribute(ctx, path.Root("referenced_by"), &referencedBy))
+		if len(referencedBy) != 1 || referencedBy[0].Subject.ValueString() != "orders-value" {
+			t.Errorf("referenced_by = %v, want orders-value", referencedBy)
+		}
+	})
+
+	t.Run("compatibility_check", func(t *testing.T) {
+		// The subject only exists in .team-a, where the schema is incompatible
+		resp := testUnitDataSourceRead(t, &compatibilityCheckDataSource{}, unscoped, map[string]tftypes.Value{
+			"subject":     tftypes.NewValue(tftypes.String, "customer-value"),
+			"context":     teamA,
+			"schema":      tftypes.NewValue(tftypes.String, testUnitSchemaIncompatible),
+			"schema_type": tftypes.NewValue(tftypes.String, "AVRO"),
+		})
+		testUnitNoErrors(t, "Read", resp.Diagnostics)
+
+		var compatible bool
+		testUnitNoErrors(t, "reading is_compatible",
+			resp.State.GetAttribute(ctx, path.Root("is_compatible"), &compatible))
+		if compatible {
+			t.Error("is_compatible = true, want the check against customer-value in .team-a to fail")
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// metadataAttribute returns the schema of a data contract metadata object.
+func metadataAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: description,
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"tags": schema.MapAttribute{
+				Description: "Tags to apply, keyed by the path of the field they apply to.",
+				Optional:    true,
+				ElementType: types.SetType{ElemType: types.StringType},
+			},
+			"properties": schema.MapAttribute{
+				Description: "Arbitrary key/value properties.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"sensitive": schema.SetAttribute{
+				Description: "Names of properties whose values are sensitive.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// ruleSetAttribute returns the schema of a data contract rule set object.
+func ruleSetAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: description,
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"migration_rules": ruleListAttribute("Rules that migrate data between schema versions."),
+			"domain_rules":    ruleListAttribute("Rules that validate or transform data of this schema."),
+		},
+	}
+}
+
+// ruleListAttribute returns the schema of a list of data contract rules.
+func ruleListAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: description,
+		Optional:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Description: "The rule name.",
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					},
+				},
+				"doc": schema.StringAttribute{
+					Description: "A description of the rule.",
+					Optional:    true,
+				},
+				"kind": schema.StringAttribute{
+					Description: "The rule kind.",
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("TRANSFORM", "CONDITION"),
+					},
+				},
+				"mode": schema.StringAttribute{
+					Description: "When the rule is applied.",
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("UPGRADE", "DOWNGRADE", "UPDOWN", "WRITE", "READ", "WRITEREAD"),
+					},
+				},
+				"type": schema.StringAttribute{
+					Description: "The rule executor type, e.g. `CEL`, `CEL_FIELD` or `JSONATA`.",
+					Required:    true,
+				},
+				"tags": schema.SetAttribute{
+					Description: "Tags of the fields the rule applies to.",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+				"params": schema.MapAttribute{
+					Description: "Parameters passed to the rule executor.",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+				"expr": schema.StringAttribute{
+					Description: "The rule expression.",
+					Optional:    true,
+				},
+				"on_success": schema.StringAttribute{
+					Description: "The action to take when the rule succeeds.",
+					Optional:    true,
+				},
+				"on_failure": schema.StringAttribute{
+					Description: "The action to take when the rule fails.",
+					Optional:    true,
+				},
+				"disabled": schema.BoolAttribute{
+					Description: "Whether the rule is disabled.",
+					Optional:    true,
+					Computed:    true,
+					Default:     booldefault.StaticBool(false),
+				},
+			},
+		},
+	}
+}
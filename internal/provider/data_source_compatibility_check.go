@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &compatibilityCheckDataSource{}
+	_ datasource.DataSourceWithConfigure = &compatibilityCheckDataSource{}
+)
+
+// NewCompatibilityCheckDataSource is a helper function to simplify the provider implementation.
+func NewCompatibilityCheckDataSource() datasource.DataSource {
+	return &compatibilityCheckDataSource{}
+}
+
+// compatibilityCheckDataSource is the data source implementation.
+type compatibilityCheckDataSource struct {
+	client        *utils.Client
+	schemaContext string
+	normalize     bool
+}
+
+// compatibilityCheckDataSourceModel describes the data source data model.
+type compatibilityCheckDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Subject      types.String `tfsdk:"subject"`
+	Context      types.String `tfsdk:"context"`
+	Schema       types.String `tfsdk:"schema"`
+	SchemaType   types.String `tfsdk:"schema_type"`
+	Reference    types.List   `tfsdk:"references"`
+	Version      types.Int64  `tfsdk:"version"`
+	AllVersions  types.Bool   `tfsdk:"all_versions"`
+	IsCompatible types.Bool   `tfsdk:"is_compatible"`
+	Messages     types.List   `tfsdk:"messages"`
+}
+
+// Metadata returns the data source type name.
+func (d *compatibilityCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_compatibility_check"
+}
+
+// Schema defines the schema for the data source.
+func (d *compatibilityCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Compatibility check data source. Tests whether the Schema Registry would accept " +
+			"a schema under a subject's compatibility level, without registering it.",
+		Description: "Tests a schema for compatibility without registering it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The subject and the version checked against, separated by a slash.",
+				Computed:    true,
+			},
+			"subject": schema.StringAttribute{
+				Description: "The subject to check the schema against.",
+				Required:    true,
+			},
+			"context": schema.StringAttribute{
+				Description: contextDescription,
+				Optional:    true,
+				Validators: []validator.String{
+					contextNameValidator(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Description: "The schema definition to check.",
+				Required:    true,
+			},
+			"schema_type": schema.StringAttribute{
+				Description: "The schema format.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"AVRO",
+						"JSON",
+						"PROTOBUF",
+					),
+				},
+			},
+			"references": schema.ListNestedAttribute{
+				Description: "The referenced schema list.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The referenced schema name.",
+							Required:    true,
+						},
+						"subject": schema.StringAttribute{
+							Description: "The referenced schema subject.",
+							Required:    true,
+						},
+						"version": schema.Int64Attribute{
+							Description: "The referenced schema version.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"version": schema.Int64Attribute{
+				Description: "The version to check against. Defaults to the latest version.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.ConflictsWith(path.MatchRoot("all_versions")),
+				},
+			},
+			"all_versions": schema.BoolAttribute{
+				Description: "Whether to check against every version the subject's compatibility level applies " +
+					"to, instead of a single version.",
+				Optional: true,
+			},
+			"is_compatible": schema.BoolAttribute{
+				Description: "Whether the registry would accept the schema.",
+				Computed:    true,
+			},
+			"messages": schema.ListAttribute{
+				Description: "The registry's reasons for an incompatibility.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *compatibilityCheckDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+	d.schemaContext = data.schemaContext
+	d.normalize = data.normalize != nil && *data.normalize
+}
+
+// Read asks the Schema Registry whether the schema is compatible.
+func (d *compatibilityCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest,
+	resp *datasource.ReadResponse) {
+	var state compatibilityCheckDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	references, diags := utils.ToRegistryReferences(ctx, state.Reference)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An empty version checks against all versions
+	version := "latest"
+	switch {
+	case state.AllVersions.ValueBool():
+		version = ""
+	case !state.Version.IsNull():
+		version = strconv.FormatInt(state.Version.ValueInt64(), 10)
+	}
+
+	schemaContext := effectiveContext(state.Context, d.schemaContext)
+	subject := utils.QualifySubject(schemaContext, state.Subject.ValueString())
+	result, err := d.client.CheckCompatibility(ctx, subject, version, utils.NewSchemaRequest(
+		state.Schema.ValueString(),
+		utils.ToSchemaType(state.SchemaType.ValueString()),
+		utils.QualifyReferences(schemaContext, references),
+	), d.normalize)
+	if err != nil && (version == "latest" || version == "") && utils.IsNotFound(err) {
+		// A subject without versions accepts any schema, whether it is checked
+		// against the latest or all versions
+		result, err = &utils.CompatibilityResult{IsCompatible: true}, nil
+	}
+	if err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Checking Compatibility",
+			fmt.Sprintf("Could not check compatibility with subject %s", subject), err)
+		return
+	}
+
+	if version == "" {
+		version = "all"
+	}
+	state.ID = types.StringValue(subject + "/" + version)
+	state.IsCompatible = types.BoolValue(result.IsCompatible)
+	messages := result.Messages
+	if messages == nil {
+		messages = []string{}
+	}
+	state.Messages, diags = types.ListValueFrom(ctx, types.StringType, messages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
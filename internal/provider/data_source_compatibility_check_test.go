@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCompatibilityCheckDataSource_basic(t *testing.T) {
+	subjectName := acctest.RandomWithPrefix("tf-acc-test")
+
+	// Adding a field without a default breaks BACKWARD compatibility
+	incompatibleSchema := `{
+    "type": "record",
+    "name": "Test",
+    "fields": [
+        {
+            "name": "f1",
+            "type": "string"
+        },
+        {
+            "name": "f2",
+            "type": "int"
+        }
+    ]
+}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCompatibilityCheckDataSourceConfig(subjectName, incompatibleSchema),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.schemaregistry_compatibility_check.compatible",
+						"is_compatible", "true"),
+					resource.TestCheckResourceAttr("data.schemaregistry_compatibility_check.compatible",
+						"id", subjectName+"/latest"),
+					resource.TestCheckResourceAttr("data.schemaregistry_compatibility_check.incompatible",
+						"is_compatible", "false"),
+					resource.TestCheckResourceAttr("data.schemaregistry_compatibility_check.incompatible",
+						"id", subjectName+"/all"),
+					// The check must not register anything
+					resource.TestCheckResourceAttr("schemaregistry_schema.test_01", "version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCompatibilityCheckDataSourceConfig(subject, incompatibleSchema string) string {
+	const template = `
+data "schemaregistry_compatibility_check" "compatible" {
+  subject     = schemaregistry_schema.test_01.subject
+  schema_type = "AVRO"
+  schema      = <<EOF
+%s
+EOF
+}
+
+data "schemaregistry_compatibility_check" "incompatible" {
+  subject      = schemaregistry_schema.test_01.subject
+  schema_type  = "AVRO"
+  all_versions = true
+  schema       = <<EOF
+%s
+EOF
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_withCompatibility(subject, initialSchema, "BACKWARD"),
+		fmt.Sprintf(template, updatedSchema, incompatibleSchema))
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCompatibilityCheckDataSource_unitMissingSubject(t *testing.T) {
+	tests := []struct {
+		name        string
+		allVersions bool
+	}{
+		{name: "latest version", allVersions: false},
+		{name: "all versions", allVersions: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, client := testUnitClient(t)
+			resp := testUnitDataSourceRead(t, &compatibilityCheckDataSource{}, &providerData{client: client},
+				map[string]tftypes.Value{
+					"subject":      tftypes.NewValue(tftypes.String, "orders-value"),
+					"schema":       tftypes.NewValue(tftypes.String, testUnitSchemaV1),
+					"schema_type":  tftypes.NewValue(tftypes.String, "AVRO"),
+					"all_versions": tftypes.NewValue(tftypes.Bool, tt.allVersions),
+				})
+			testUnitNoErrors(t, "Read", resp.Diagnostics)
+
+			var m compatibilityCheckDataSourceModel
+			testUnitNoErrors(t, "reading the state", resp.State.Get(context.Background(), &m))
+			if !m.IsCompatible.ValueBool() {
+				t.Errorf("Read is_compatible = %s, want true for a subject without versions", m.IsCompatible)
+			}
+		})
+	}
+}
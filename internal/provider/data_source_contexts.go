@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &contextsDataSource{}
+	_ datasource.DataSourceWithConfigure = &contextsDataSource{}
+)
+
+// NewContextsDataSource is a helper function to simplify the provider implementation.
+func NewContextsDataSource() datasource.DataSource {
+	return &contextsDataSource{}
+}
+
+// contextsDataSource is the data source implementation.
+type contextsDataSource struct {
+	client *utils.Client
+}
+
+// contextsDataSourceModel describes the data source data model.
+type contextsDataSourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Contexts types.List   `tfsdk:"contexts"`
+}
+
+// Metadata returns the data source type name.
+func (d *contextsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_contexts"
+}
+
+// Schema defines the schema for the data source.
+func (d *contextsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Contexts data source. Lists the schema contexts in the Schema Registry.",
+		Description:         "Lists the schema contexts in the Schema Registry.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "A static identifier for the data source.",
+				Computed:    true,
+			},
+			"contexts": schema.ListAttribute{
+				Description: "The context names, sorted. The default context is `.`.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *contextsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+// Read lists the schema contexts in the Schema Registry.
+func (d *contextsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state contextsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contexts, err := d.client.GetContexts(ctx)
+	if err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Reading Contexts", "Could not list schema contexts", err)
+		return
+	}
+	sort.Strings(contexts)
+
+	state.ID = types.StringValue("contexts")
+	state.Contexts, diags = types.ListValueFrom(ctx, types.StringType, contexts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccContextsDataSource_basic(t *testing.T) {
+	testAccPreCheckFeature(t, "contexts")
+	name := acctest.RandomWithPrefix("tf-acc-test-ctx")
+	datasourceName := "data.schemaregistry_contexts.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccContextsDataSourceConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "id", "contexts"),
+					resource.TestCheckTypeSetElemAttr(datasourceName, "contexts.*", "."),
+					resource.TestCheckTypeSetElemAttr(datasourceName, "contexts.*", "."+name),
+				),
+			},
+		},
+	})
+}
+
+func testAccContextsDataSourceConfig(name string) string {
+	const template = `
+resource "schemaregistry_schema" "test" {
+  subject     = "%[1]s-value"
+  context     = ".%[1]s"
+  schema_type = "JSON"
+  schema      = jsonencode({ "type" : "string" })
+}
+
+data "schemaregistry_contexts" "test" {
+  depends_on = [schemaregistry_schema.test]
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_base(), fmt.Sprintf(template, name))
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &globalConfigDataSource{}
+	_ datasource.DataSourceWithConfigure = &globalConfigDataSource{}
+)
+
+// NewGlobalConfigDataSource is a helper function to simplify the provider implementation.
+func NewGlobalConfigDataSource() datasource.DataSource {
+	return &globalConfigDataSource{}
+}
+
+// globalConfigDataSource is the data source implementation.
+type globalConfigDataSource struct {
+	client *utils.Client
+}
+
+// Metadata returns the data source type name.
+func (d *globalConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_global_config"
+}
+
+// Schema defines the schema for the data source.
+func (d *globalConfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Global config data source. Fetches the registry-wide configuration that subjects " +
+			"without a config of their own inherit.",
+		Description: "Fetches the global configuration of the Schema Registry.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always `global`.",
+				Computed:    true,
+			},
+			"compatibility_level": schema.StringAttribute{
+				Description: "The global compatibility level.",
+				Computed:    true,
+			},
+			"normalize": schema.BoolAttribute{
+				Description: "Whether schemas are normalized by default.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *globalConfigDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *globalConfigDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state globalConfigModel
+	if err := readGlobalConfig(ctx, d.client, &state); err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Reading Global Config", "Could not read global config", err)
+		return
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &modeDataSource{}
+	_ datasource.DataSourceWithConfigure = &modeDataSource{}
+)
+
+// NewModeDataSource is a helper function to simplify the provider implementation.
+func NewModeDataSource() datasource.DataSource {
+	return &modeDataSource{}
+}
+
+// modeDataSource is the data source implementation.
+type modeDataSource struct {
+	client        *utils.Client
+	schemaContext string
+}
+
+// modeDataSourceModel describes the data source data model.
+type modeDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Subject types.String `tfsdk:"subject"`
+	Mode    types.String `tfsdk:"mode"`
+}
+
+// Metadata returns the data source type name.
+func (d *modeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mode"
+}
+
+// Schema defines the schema for the data source.
+func (d *modeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Mode data source. Fetches the effective mode of a subject, or the registry mode " +
+			"when `subject` is not set.",
+		Description: "Fetches the mode of a subject or of the Schema Registry.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The subject, or `global` for the registry mode.",
+				Computed:    true,
+			},
+			"subject": schema.StringAttribute{
+				Description: "The subject to read the mode of. Subjects without a mode of their own report the " +
+					"registry mode. Unqualified subjects are looked up in the provider `context`.",
+				Optional: true,
+			},
+			"mode": schema.StringAttribute{
+				Description: "The mode: `READWRITE`, `READONLY`, `READONLY_OVERRIDE` or `IMPORT`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *modeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+	d.schemaContext = data.schemaContext
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *modeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state modeDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subject := state.Subject.ValueString()
+	if subject != "" {
+		subject = utils.QualifySubject(d.schemaContext, subject)
+	}
+	mode, err := d.client.GetMode(ctx, subject, true)
+	if err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Reading Mode", fmt.Sprintf("Could not read mode of %s", modeTarget(subject)), err)
+		return
+	}
+
+	state.ID = types.StringValue(modeID(subject))
+	state.Mode = types.StringValue(mode)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
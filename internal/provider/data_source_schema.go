@@ -3,15 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
-	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/riferrei/srclient"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -27,20 +26,22 @@ func NewSchemaDataSource() datasource.DataSource {
 
 // schemaDataSource is the data source implementation.
 type schemaDataSource struct {
-	client *srclient.SchemaRegistryClient
+	client        *utils.Client
+	schemaContext string
 }
 
 // schemaDataSourceModel describes the data source data model.
 type schemaDataSourceModel struct {
-	ID                 types.String         `tfsdk:"id"`
-	Subject            types.String         `tfsdk:"subject"`
-	Schema             jsontypes.Normalized `tfsdk:"schema"`
-	SchemaID           types.Int64          `tfsdk:"schema_id"`
-	SchemaType         types.String         `tfsdk:"schema_type"`
-	Version            types.Int64          `tfsdk:"version"`
-	Reference          types.List           `tfsdk:"reference"`
-	CompatibilityLevel types.String         `tfsdk:"compatibility_level"`
-	HardDelete         types.Bool           `tfsdk:"hard_delete"`
+	ID                 types.String `tfsdk:"id"`
+	Subject            types.String `tfsdk:"subject"`
+	Context            types.String `tfsdk:"context"`
+	Schema             types.String `tfsdk:"schema"`
+	SchemaID           types.Int64  `tfsdk:"schema_id"`
+	SchemaType         types.String `tfsdk:"schema_type"`
+	Version            types.Int64  `tfsdk:"version"`
+	Reference          types.List   `tfsdk:"reference"`
+	CompatibilityLevel types.String `tfsdk:"compatibility_level"`
+	HardDelete         types.Bool   `tfsdk:"hard_delete"`
 }
 
 // Metadata returns the data source type name.
@@ -64,10 +65,17 @@ func (d *schemaDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Description: "The subject related to the schema.",
 				Required:    true,
 			},
+			"context": schema.StringAttribute{
+				Description: contextDescription,
+				Optional:    true,
+				Validators: []validator.String{
+					contextNameValidator(),
+				},
+			},
 			"schema": schema.StringAttribute{
-				Description: "The schema definition.",
-				Computed:    true,
-				CustomType:  jsontypes.NormalizedType{},
+				Description: "The schema definition. AVRO and JSON schemas are compared as JSON documents; " +
+					"PROTOBUF schemas are compared ignoring whitespace, comments and declaration order.",
+				Computed: true,
 			},
 			"schema_id": schema.Int64Attribute{
 				Description: "The ID of the schema.",
@@ -138,16 +146,17 @@ func (d *schemaDataSource) Configure(_ context.Context, req datasource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*srclient.SchemaRegistryClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *srclient.SchemaRegistryClient, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = data.client
+	d.schemaContext = data.schemaContext
 }
 
 // Read fetches the schema details from the Schema Registry.
@@ -160,29 +169,26 @@ func (d *schemaDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	subject := inputs.Subject.ValueString()
+	schemaContext := effectiveContext(inputs.Context, d.schemaContext)
+	subject := utils.QualifySubject(schemaContext, inputs.Subject.ValueString())
 	version := inputs.Version.ValueInt64()
 
 	// Fetch schema and compatibility level
-	schema, err := d.fetchSchema(subject, version)
+	schema, err := d.fetchSchema(ctx, subject, version)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Schema",
-			"Could not read schema: "+err.Error())
+		addRegistryError(&resp.Diagnostics, "Error Reading Schema", "Could not read schema", err)
 		return
 	}
 
-	compatibilityLevel, err := d.fetchCompatibilityLevel(subject)
+	compatibilityLevel, err := d.client.GetCompatibilityLevel(ctx, subject)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Compatibility Level",
-			"Could not read compatibility level: "+err.Error(),
-		)
+		addRegistryError(&resp.Diagnostics, "Error Reading Compatibility Level",
+			"Could not read compatibility level", err)
 		return
 	}
 
 	// Map response body to schema data source model
-	outputs := d.mapSchemaToOutputs(subject, schema, compatibilityLevel)
+	outputs := d.mapSchemaToOutputs(inputs, schemaContext, schema, compatibilityLevel)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, outputs)
@@ -193,29 +199,26 @@ func (d *schemaDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 }
 
 // fetchSchema retrieves the schema from the registry, either by specific version or the latest version.
-func (d *schemaDataSource) fetchSchema(subject string, version int64) (*srclient.Schema, error) {
+func (d *schemaDataSource) fetchSchema(ctx context.Context, subject string, version int64) (*utils.RegisteredSchema,
+	error) {
 	if version > 0 {
-		return d.client.GetSchemaByVersion(subject, int(version))
+		return d.client.GetSubjectVersion(ctx, subject, strconv.FormatInt(version, 10), false)
 	}
-	return d.client.GetLatestSchema(subject)
-}
-
-// fetchCompatibilityLevel retrieves the compatibility level for the given subject.
-func (d *schemaDataSource) fetchCompatibilityLevel(subject string) (*srclient.CompatibilityLevel, error) {
-	return d.client.GetCompatibilityLevel(subject, true)
+	return d.client.GetSubjectVersion(ctx, subject, "latest", false)
 }
 
 // mapSchemaToOutputs maps the schema and compatibility level to the schema data source model.
-func (d *schemaDataSource) mapSchemaToOutputs(subject string, schema *srclient.Schema,
-	compatibilityLevel *srclient.CompatibilityLevel) schemaDataSourceModel {
+func (d *schemaDataSource) mapSchemaToOutputs(inputs schemaDataSourceModel, schemaContext string,
+	schema *utils.RegisteredSchema, compatibilityLevel string) schemaDataSourceModel {
 	return schemaDataSourceModel{
-		ID:                 types.StringValue(subject),
-		Subject:            types.StringValue(subject),
-		Schema:             jsontypes.NewNormalizedValue(schema.Schema()),
-		SchemaID:           types.Int64Value(int64(schema.ID())),
-		SchemaType:         types.StringValue(utils.FromSchemaType(schema.SchemaType())),
-		Version:            types.Int64Value(int64(schema.Version())),
-		Reference:          utils.FromRegistryReferences(schema.References()),
-		CompatibilityLevel: types.StringValue(utils.FromCompatibilityLevelType(*compatibilityLevel)),
+		ID:                 types.StringValue(utils.QualifySubject(schemaContext, inputs.Subject.ValueString())),
+		Subject:            inputs.Subject,
+		Context:            inputs.Context,
+		Schema:             types.StringValue(schema.Schema),
+		SchemaID:           types.Int64Value(int64(schema.ID)),
+		SchemaType:         types.StringValue(schema.Type()),
+		Version:            types.Int64Value(int64(schema.Version)),
+		Reference:          utils.FromRegistryReferences(utils.UnqualifyReferences(schemaContext, schema.References)),
+		CompatibilityLevel: types.StringValue(compatibilityLevel),
 	}
 }
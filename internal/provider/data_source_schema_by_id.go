@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &schemaByIDDataSource{}
+	_ datasource.DataSourceWithConfigure = &schemaByIDDataSource{}
+)
+
+// NewSchemaByIDDataSource is a helper function to simplify the provider implementation.
+func NewSchemaByIDDataSource() datasource.DataSource {
+	return &schemaByIDDataSource{}
+}
+
+// schemaByIDDataSource is the data source implementation.
+type schemaByIDDataSource struct {
+	client        *utils.Client
+	schemaContext string
+}
+
+// schemaByIDDataSourceModel describes the data source data model.
+type schemaByIDDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	SchemaID        types.Int64  `tfsdk:"schema_id"`
+	Context         types.String `tfsdk:"context"`
+	Schema          types.String `tfsdk:"schema"`
+	SchemaType      types.String `tfsdk:"schema_type"`
+	Reference       types.List   `tfsdk:"references"`
+	SubjectVersions types.List   `tfsdk:"subject_versions"`
+}
+
+// subjectVersionAttrTypes are the attribute types of a subject/version pair.
+var subjectVersionAttrTypes = map[string]attr.Type{
+	"subject": types.StringType,
+	"version": types.Int64Type,
+}
+
+// Metadata returns the data source type name.
+func (d *schemaByIDDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema_by_id"
+}
+
+// Schema defines the schema for the data source.
+func (d *schemaByIDDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Schema by ID data source. Fetches a schema by its global ID, together with every " +
+			"subject and version that uses it.",
+		Description: "Fetches a schema from the Schema Registry by its global ID.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The schema ID as a string.",
+				Computed:    true,
+			},
+			"schema_id": schema.Int64Attribute{
+				Description: "The global ID of the schema.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"context": schema.StringAttribute{
+				Description: "The schema context the ID is assigned in, e.g. `.team-a`. Subjects in the context " +
+					"are returned unqualified. Defaults to the provider `context`.",
+				Optional: true,
+				Validators: []validator.String{
+					contextNameValidator(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Description: "The schema definition.",
+				Computed:    true,
+			},
+			"schema_type": schema.StringAttribute{
+				Description: "The schema format.",
+				Computed:    true,
+			},
+			"references": schema.ListNestedAttribute{
+				Description: "The referenced schema list.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The referenced schema name.",
+							Computed:    true,
+						},
+						"subject": schema.StringAttribute{
+							Description: "The referenced schema subject.",
+							Computed:    true,
+						},
+						"version": schema.Int64Attribute{
+							Description: "The referenced schema version.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"subject_versions": schema.ListNestedAttribute{
+				Description: "Every subject and version that uses the schema.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"subject": schema.StringAttribute{
+							Description: "The subject.",
+							Computed:    true,
+						},
+						"version": schema.Int64Attribute{
+							Description: "The version of the subject.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *schemaByIDDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+	d.schemaContext = data.schemaContext
+}
+
+// Read fetches the schema and the subjects using it from the Schema Registry.
+func (d *schemaByIDDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state schemaByIDDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// IDs are assigned separately in each schema context
+	schemaContext := effectiveContext(state.Context, d.schemaContext)
+	scope := utils.QualifySubject(schemaContext, "")
+	id := int(state.SchemaID.ValueInt64())
+	schema, err := d.client.GetSchemaByID(ctx, id, scope)
+	if err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Reading Schema", fmt.Sprintf("Could not read schema %d", id), err)
+		return
+	}
+
+	versions, err := d.client.GetSchemaSubjectVersions(ctx, id, scope)
+	if err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Reading Schema",
+			fmt.Sprintf("Could not read the subjects using schema %d", id), err)
+		return
+	}
+
+	state.ID = types.StringValue(strconv.Itoa(id))
+	state.Schema = types.StringValue(schema.Schema)
+	state.SchemaType = types.StringValue(schema.Type())
+	state.Reference = utils.FromRegistryReferences(utils.UnqualifyReferences(schemaContext, schema.References))
+	state.SubjectVersions, diags = subjectVersionsValue(schemaContext, versions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// subjectVersionsValue converts subject/version pairs into a list value, with
+// the subjects in schemaContext unqualified.
+func subjectVersionsValue(schemaContext string, versions []utils.SubjectVersion) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	objectType := types.ObjectType{AttrTypes: subjectVersionAttrTypes}
+
+	elems := make([]attr.Value, 0, len(versions))
+	for _, v := range versions {
+		obj, d := types.ObjectValue(subjectVersionAttrTypes, map[string]attr.Value{
+			"subject": types.StringValue(utils.UnqualifySubject(schemaContext, v.Subject)),
+			"version": types.Int64Value(int64(v.Version)),
+		})
+		diags.Append(d...)
+		elems = append(elems, obj)
+	}
+	if diags.HasError() {
+		return types.ListNull(objectType), diags
+	}
+
+	out, d := types.ListValue(objectType, elems)
+	diags.Append(d...)
+	return out, diags
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSchemaByIDDataSource_basic(t *testing.T) {
+	datasourceName := "data.schemaregistry_schema_by_id.test"
+	subjectName := acctest.RandomWithPrefix("tf-acc-test-subject")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaByIDDataSourceConfig(subjectName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceName, "schema_id", "schemaregistry_schema.test_01", "schema_id"),
+					resource.TestCheckResourceAttr(datasourceName, "schema_type", "AVRO"),
+					resource.TestCheckResourceAttrWith(datasourceName, "schema", func(state string) error {
+						return ValidateSchemaString("AVRO", initialSchema, state)
+					}),
+					resource.TestCheckResourceAttr(datasourceName, "subject_versions.#", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "subject_versions.0.subject", subjectName),
+					resource.TestCheckResourceAttr(datasourceName, "subject_versions.0.version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSchemaByIDDataSourceConfig(subject string) string {
+	const template = `
+data "schemaregistry_schema_by_id" "test" {
+  schema_id = schemaregistry_schema.test_01.schema_id
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_basic(subject), template)
+}
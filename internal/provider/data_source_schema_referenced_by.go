@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &schemaReferencedByDataSource{}
+	_ datasource.DataSourceWithConfigure = &schemaReferencedByDataSource{}
+)
+
+// NewSchemaReferencedByDataSource is a helper function to simplify the provider implementation.
+func NewSchemaReferencedByDataSource() datasource.DataSource {
+	return &schemaReferencedByDataSource{}
+}
+
+// schemaReferencedByDataSource is the data source implementation.
+type schemaReferencedByDataSource struct {
+	client        *utils.Client
+	schemaContext string
+}
+
+// schemaReferencedByDataSourceModel describes the data source data model.
+type schemaReferencedByDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Subject      types.String `tfsdk:"subject"`
+	Context      types.String `tfsdk:"context"`
+	Version      types.Int64  `tfsdk:"version"`
+	SchemaIDs    types.List   `tfsdk:"schema_ids"`
+	ReferencedBy types.List   `tfsdk:"referenced_by"`
+}
+
+// Metadata returns the data source type name.
+func (d *schemaReferencedByDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema_referenced_by"
+}
+
+// Schema defines the schema for the data source.
+func (d *schemaReferencedByDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Schema referenced by data source. Lists the schemas that reference a version " +
+			"of a subject.",
+		Description: "Lists the schemas that reference a version of a subject.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The subject and version, separated by a slash.",
+				Computed:    true,
+			},
+			"subject": schema.StringAttribute{
+				Description: "The referenced subject.",
+				Required:    true,
+			},
+			"context": schema.StringAttribute{
+				Description: contextDescription,
+				Optional:    true,
+				Validators: []validator.String{
+					contextNameValidator(),
+				},
+			},
+			"version": schema.Int64Attribute{
+				Description: "The referenced version. Defaults to the latest version.",
+				Optional:    true,
+			},
+			"schema_ids": schema.ListAttribute{
+				Description: "The IDs of the schemas that reference the version.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"referenced_by": schema.ListNestedAttribute{
+				Description: "The subject versions that reference the version, sorted by subject and version.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"subject": schema.StringAttribute{
+							Description: "The referencing subject.",
+							Computed:    true,
+						},
+						"version": schema.Int64Attribute{
+							Description: "The referencing version.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *schemaReferencedByDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+	d.schemaContext = data.schemaContext
+}
+
+// Read fetches the referencing schemas from the Schema Registry.
+func (d *schemaReferencedByDataSource) Read(ctx context.Context, req datasource.ReadRequest,
+	resp *datasource.ReadResponse) {
+	var state schemaReferencedByDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schemaContext := effectiveContext(state.Context, d.schemaContext)
+	subject := utils.QualifySubject(schemaContext, state.Subject.ValueString())
+	version := "latest"
+	if !state.Version.IsNull() {
+		version = strconv.FormatInt(state.Version.ValueInt64(), 10)
+	}
+
+	ids, referencing, err := d.client.GetReferencingSubjectVersions(ctx, subject, version)
+	if err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Reading Schema References",
+			fmt.Sprintf("Could not read references to version %s of subject %s", version, subject), err)
+		return
+	}
+
+	state.ID = types.StringValue(subject + "/" + version)
+	state.SchemaIDs, diags = types.ListValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+	state.ReferencedBy, diags = subjectVersionsValue(schemaContext, referencing)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSchemaReferencedByDataSource_basic(t *testing.T) {
+	datasourceName := "data.schemaregistry_schema_referenced_by.test"
+	subjectName := acctest.RandomWithPrefix("tf-acc-test")
+	refName := acctest.RandomWithPrefix("tf-acc-test-ref")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: ConfigCompose(testAccSchemaResourceConfig_referenced(subjectName, refName, false, true), `
+data "schemaregistry_schema_referenced_by" "test" {
+  subject = schemaregistry_schema.ref_01.subject
+  version = 1
+
+  depends_on = [schemaregistry_schema.test_01]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "id", refName+"/1"),
+					resource.TestCheckResourceAttr(datasourceName, "schema_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(datasourceName, "schema_ids.0",
+						"schemaregistry_schema.test_01", "schema_id"),
+					resource.TestCheckResourceAttr(datasourceName, "referenced_by.#", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "referenced_by.0.subject", subjectName),
+					resource.TestCheckResourceAttr(datasourceName, "referenced_by.0.version", "1"),
+				),
+			},
+		},
+	})
+}
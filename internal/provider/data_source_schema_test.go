@@ -22,7 +22,7 @@ func TestAccSchemaDataSource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(datasourceName, "schema_type", "AVRO"),
 					resource.TestCheckResourceAttr(datasourceName, "compatibility_level", "NONE"),
 					resource.TestCheckResourceAttrWith(datasourceName, "schema", func(state string) error {
-						return ValidateSchemaString(initialSchema, state)
+						return ValidateSchemaString("AVRO", initialSchema, state)
 					}),
 				),
 			},
@@ -46,7 +46,7 @@ func TestAccSchemaDataSource_multipleVersions(t *testing.T) {
 					resource.TestCheckResourceAttr(datasourceName, "schema_type", "AVRO"),
 					resource.TestCheckResourceAttr(datasourceName, "compatibility_level", "NONE"),
 					resource.TestCheckResourceAttrWith(datasourceName, "schema", func(state string) error {
-						return ValidateSchemaString(initialSchema, state)
+						return ValidateSchemaString("AVRO", initialSchema, state)
 					}),
 				),
 			},
@@ -59,7 +59,7 @@ func TestAccSchemaDataSource_multipleVersions(t *testing.T) {
 					resource.TestCheckResourceAttr(datasourceName, "schema_type", "AVRO"),
 					resource.TestCheckResourceAttr(datasourceName, "compatibility_level", "BACKWARD"),
 					resource.TestCheckResourceAttrWith(datasourceName, "schema", func(state string) error {
-						return ValidateSchemaString(updatedSchema, state)
+						return ValidateSchemaString("AVRO", updatedSchema, state)
 					}),
 				),
 			},
@@ -73,7 +73,7 @@ func TestAccSchemaDataSource_multipleVersions(t *testing.T) {
 					resource.TestCheckResourceAttr(datasourceName, "compatibility_level", "BACKWARD"),
 					resource.TestCheckResourceAttr(datasourceName, "version", "2"),
 					resource.TestCheckResourceAttrWith(datasourceName, "schema", func(state string) error {
-						return ValidateSchemaString(updatedSchema, state)
+						return ValidateSchemaString("AVRO", updatedSchema, state)
 					}),
 				),
 			},
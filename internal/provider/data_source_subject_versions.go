@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &subjectVersionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &subjectVersionsDataSource{}
+)
+
+// NewSubjectVersionsDataSource is a helper function to simplify the provider implementation.
+func NewSubjectVersionsDataSource() datasource.DataSource {
+	return &subjectVersionsDataSource{}
+}
+
+// subjectVersionsDataSource is the data source implementation.
+type subjectVersionsDataSource struct {
+	client        *utils.Client
+	schemaContext string
+}
+
+// subjectVersionsDataSourceModel describes the data source data model.
+type subjectVersionsDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Subject        types.String `tfsdk:"subject"`
+	Context        types.String `tfsdk:"context"`
+	IncludeDeleted types.Bool   `tfsdk:"include_deleted"`
+	Versions       types.List   `tfsdk:"versions"`
+}
+
+// schemaVersionAttrTypes are the attribute types of an entry in `versions`.
+var schemaVersionAttrTypes = map[string]attr.Type{
+	"version":     types.Int64Type,
+	"schema_id":   types.Int64Type,
+	"schema":      types.StringType,
+	"schema_type": types.StringType,
+	"references":  types.ListType{ElemType: types.ObjectType{AttrTypes: utils.ReferenceAttrTypes}},
+	"deleted":     types.BoolType,
+}
+
+// Metadata returns the data source type name.
+func (d *subjectVersionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subject_versions"
+}
+
+// Schema defines the schema for the data source.
+func (d *subjectVersionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Subject versions data source. Fetches every version of a subject from the Schema Registry.",
+		Description:         "Fetches every version of a subject from the Schema Registry.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The subject.",
+				Computed:    true,
+			},
+			"subject": schema.StringAttribute{
+				Description: "The subject to list the versions of.",
+				Required:    true,
+			},
+			"context": schema.StringAttribute{
+				Description: contextDescription,
+				Optional:    true,
+				Validators: []validator.String{
+					contextNameValidator(),
+				},
+			},
+			"include_deleted": schema.BoolAttribute{
+				Description: "Whether to include soft-deleted versions. Defaults to false.",
+				Optional:    true,
+			},
+			"versions": schema.ListNestedAttribute{
+				Description: "The versions of the subject, oldest first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.Int64Attribute{
+							Description: "The version number.",
+							Computed:    true,
+						},
+						"schema_id": schema.Int64Attribute{
+							Description: "The global ID of the schema.",
+							Computed:    true,
+						},
+						"schema": schema.StringAttribute{
+							Description: "The schema definition.",
+							Computed:    true,
+						},
+						"schema_type": schema.StringAttribute{
+							Description: "The schema format.",
+							Computed:    true,
+						},
+						"references": schema.ListNestedAttribute{
+							Description: "The referenced schema list.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Description: "The referenced schema name.",
+										Computed:    true,
+									},
+									"subject": schema.StringAttribute{
+										Description: "The referenced schema subject.",
+										Computed:    true,
+									},
+									"version": schema.Int64Attribute{
+										Description: "The referenced schema version.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+						"deleted": schema.BoolAttribute{
+							Description: "Whether the version is soft-deleted.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *subjectVersionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+	d.schemaContext = data.schemaContext
+}
+
+// Read fetches every version of the subject from the Schema Registry.
+func (d *subjectVersionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state subjectVersionsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subject := qualifySubject(state.Context, d.schemaContext, state.Subject.ValueString())
+	includeDeleted := state.IncludeDeleted.ValueBool()
+	active, err := d.client.ListSubjectVersions(ctx, subject, false)
+	if err != nil && !(includeDeleted && utils.IsNotFound(err)) {
+		addRegistryError(&resp.Diagnostics, "Error Reading Subject Versions",
+			fmt.Sprintf("Could not list versions of subject %s", subject), err)
+		return
+	}
+
+	versions := active
+	if includeDeleted {
+		versions, err = d.client.ListSubjectVersions(ctx, subject, true)
+		if err != nil {
+			addRegistryError(&resp.Diagnostics, "Error Reading Subject Versions",
+				fmt.Sprintf("Could not list versions of subject %s", subject), err)
+			return
+		}
+	}
+	slices.Sort(versions)
+
+	schemas := make([]*utils.RegisteredSchema, 0, len(versions))
+	for _, version := range versions {
+		schema, err := d.client.GetSubjectVersion(ctx, subject, strconv.Itoa(version), includeDeleted)
+		if err != nil {
+			addRegistryError(&resp.Diagnostics, "Error Reading Subject Versions",
+				fmt.Sprintf("Could not read version %d of subject %s", version, subject), err)
+			return
+		}
+		schemas = append(schemas, schema)
+	}
+
+	state.ID = types.StringValue(subject)
+	state.Versions, diags = schemaVersionsValue(schemas, active)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// schemaVersionsValue converts subject versions into a list value. Versions
+// missing from active are reported as soft-deleted.
+func schemaVersionsValue(schemas []*utils.RegisteredSchema, active []int) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	objectType := types.ObjectType{AttrTypes: schemaVersionAttrTypes}
+
+	elems := make([]attr.Value, 0, len(schemas))
+	for _, schema := range schemas {
+		references := utils.FromRegistryReferences(schema.References)
+		obj, d := types.ObjectValue(schemaVersionAttrTypes, map[string]attr.Value{
+			"version":     types.Int64Value(int64(schema.Version)),
+			"schema_id":   types.Int64Value(int64(schema.ID)),
+			"schema":      types.StringValue(schema.Schema),
+			"schema_type": types.StringValue(schema.Type()),
+			"references":  references,
+			"deleted":     types.BoolValue(!slices.Contains(active, schema.Version)),
+		})
+		diags.Append(d...)
+		elems = append(elems, obj)
+	}
+	if diags.HasError() {
+		return types.ListNull(objectType), diags
+	}
+
+	out, d := types.ListValue(objectType, elems)
+	diags.Append(d...)
+	return out, diags
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSubjectVersionsDataSource_basic(t *testing.T) {
+	datasourceName := "data.schemaregistry_subject_versions.test"
+	subjectName := acctest.RandomWithPrefix("tf-acc-test-subject")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaResourceConfig_basic(subjectName),
+			},
+			{
+				Config: testAccSubjectVersionsDataSourceConfig(subjectName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "id", subjectName),
+					resource.TestCheckResourceAttr(datasourceName, "versions.#", "2"),
+					resource.TestCheckResourceAttr(datasourceName, "versions.0.version", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "versions.0.schema_type", "AVRO"),
+					resource.TestCheckResourceAttr(datasourceName, "versions.0.deleted", "false"),
+					resource.TestCheckResourceAttrWith(datasourceName, "versions.0.schema", func(state string) error {
+						return ValidateSchemaString("AVRO", initialSchema, state)
+					}),
+					resource.TestCheckResourceAttr(datasourceName, "versions.1.version", "2"),
+					resource.TestCheckResourceAttrPair(datasourceName, "versions.1.schema_id",
+						"schemaregistry_schema.test_01", "schema_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSubjectVersionsDataSourceConfig(subject string) string {
+	const template = `
+data "schemaregistry_subject_versions" "test" {
+  subject = schemaregistry_schema.test_01.subject
+
+  depends_on = [schemaregistry_schema.test_01]
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_basicUpdate(subject), template)
+}
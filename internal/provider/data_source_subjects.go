@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &subjectsDataSource{}
+	_ datasource.DataSourceWithConfigure = &subjectsDataSource{}
+)
+
+// NewSubjectsDataSource is a helper function to simplify the provider implementation.
+func NewSubjectsDataSource() datasource.DataSource {
+	return &subjectsDataSource{}
+}
+
+// subjectsDataSource is the data source implementation.
+type subjectsDataSource struct {
+	client        *utils.Client
+	schemaContext string
+}
+
+// subjectsDataSourceModel describes the data source data model.
+type subjectsDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	SubjectPrefix types.String `tfsdk:"subject_prefix"`
+	Context       types.String `tfsdk:"context"`
+	Pattern       types.String `tfsdk:"pattern"`
+	Deleted       types.Bool   `tfsdk:"deleted"`
+	SchemaType    types.String `tfsdk:"schema_type"`
+	IncludeLatest types.Bool   `tfsdk:"include_latest"`
+	Subjects      types.List   `tfsdk:"subjects"`
+	Latest        types.Map    `tfsdk:"latest"`
+}
+
+// latestVersionAttrTypes are the attribute types of an entry in `latest`.
+var latestVersionAttrTypes = map[string]attr.Type{
+	"version":     types.Int64Type,
+	"schema_id":   types.Int64Type,
+	"schema_type": types.StringType,
+}
+
+// Metadata returns the data source type name.
+func (d *subjectsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subjects"
+}
+
+// Schema defines the schema for the data source.
+func (d *subjectsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Subjects data source. Lists the subjects in the Schema Registry, optionally " +
+			"filtered by prefix, pattern and schema type.",
+		Description: "Lists the subjects in the Schema Registry.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "An identifier for the filter combination.",
+				Computed:    true,
+			},
+			"subject_prefix": schema.StringAttribute{
+				Description: "Only list subjects starting with this prefix. Filtered by the registry.",
+				Optional:    true,
+			},
+			"context": schema.StringAttribute{
+				Description: "The schema context to list the subjects of, e.g. `.team-a`. Subjects in the context " +
+					"are returned unqualified. Defaults to the provider `context`.",
+				Optional: true,
+				Validators: []validator.String{
+					contextNameValidator(),
+				},
+			},
+			"pattern": schema.StringAttribute{
+				Description: "Only list subjects matching this regular expression.",
+				Optional:    true,
+				Validators: []validator.String{
+					regexValidator{},
+				},
+			},
+			"deleted": schema.BoolAttribute{
+				Description: "Whether to include soft-deleted subjects. Defaults to false.",
+				Optional:    true,
+			},
+			"schema_type": schema.StringAttribute{
+				Description: "Only list subjects whose latest version has this schema format. Requires a lookup per subject.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"AVRO",
+						"JSON",
+						"PROTOBUF",
+					),
+				},
+			},
+			"include_latest": schema.BoolAttribute{
+				Description: "Whether to populate `latest`. Requires a lookup per subject. Defaults to false.",
+				Optional:    true,
+			},
+			"subjects": schema.ListAttribute{
+				Description: "The matching subject names, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"latest": schema.MapNestedAttribute{
+				Description: "The latest version of each matching subject, keyed by subject. Only set when " +
+					"`include_latest` is true.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.Int64Attribute{
+							Description: "The latest version of the subject.",
+							Computed:    true,
+						},
+						"schema_id": schema.Int64Attribute{
+							Description: "The ID of the latest schema.",
+							Computed:    true,
+						},
+						"schema_type": schema.StringAttribute{
+							Description: "The format of the latest schema.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *subjectsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+	d.schemaContext = data.schemaContext
+}
+
+// Read lists and filters the subjects in the Schema Registry.
+func (d *subjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state subjectsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schemaContext := effectiveContext(state.Context, d.schemaContext)
+	prefix := utils.QualifySubject(schemaContext, state.SubjectPrefix.ValueString())
+	deleted := state.Deleted.ValueBool()
+	subjects, err := d.client.ListSubjects(ctx, prefix, deleted)
+	if err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Reading Subjects", "Could not list subjects", err)
+		return
+	}
+	for i, subject := range subjects {
+		subjects[i] = utils.UnqualifySubject(schemaContext, subject)
+	}
+
+	if !state.Pattern.IsNull() {
+		pattern, err := regexp.Compile(state.Pattern.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("pattern"), "Invalid regular expression", err.Error())
+			return
+		}
+		subjects = filterSubjects(subjects, pattern.MatchString)
+	}
+	sort.Strings(subjects)
+
+	latest := make(map[string]*utils.RegisteredSchema)
+	if state.IncludeLatest.ValueBool() || !state.SchemaType.IsNull() {
+		found := make([]string, 0, len(subjects))
+		for _, subject := range subjects {
+			schema, err := d.client.GetSubjectVersion(ctx, utils.QualifySubject(schemaContext, subject), "latest",
+				deleted)
+			if utils.IsNotFound(err) {
+				// The subject was deleted since it was listed
+				tflog.Debug(ctx, "Subject not found when reading its latest version, skipping it",
+					map[string]interface{}{"subject": subject})
+				continue
+			}
+			if err != nil {
+				addRegistryError(&resp.Diagnostics, "Error Reading Subjects",
+					fmt.Sprintf("Could not read the latest version of subject %s", subject), err)
+				return
+			}
+			latest[subject] = schema
+			found = append(found, subject)
+		}
+		subjects = found
+	}
+
+	if !state.SchemaType.IsNull() {
+		schemaType := state.SchemaType.ValueString()
+		subjects = filterSubjects(subjects, func(subject string) bool {
+			return latest[subject].Type() == schemaType
+		})
+	}
+
+	state.ID = types.StringValue(fmt.Sprintf("%s|%s|%t|%s", prefix, state.Pattern.ValueString(), deleted,
+		state.SchemaType.ValueString()))
+	state.Subjects, diags = types.ListValueFrom(ctx, types.StringType, subjects)
+	resp.Diagnostics.Append(diags...)
+	state.Latest = types.MapNull(types.ObjectType{AttrTypes: latestVersionAttrTypes})
+	if state.IncludeLatest.ValueBool() {
+		state.Latest, diags = latestVersionsValue(subjects, latest)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// filterSubjects returns the subjects for which keep returns true.
+func filterSubjects(subjects []string, keep func(string) bool) []string {
+	filtered := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		if keep(subject) {
+			filtered = append(filtered, subject)
+		}
+	}
+	return filtered
+}
+
+// latestVersionsValue builds the `latest` map for the given subjects.
+func latestVersionsValue(subjects []string, latest map[string]*utils.RegisteredSchema) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	objectType := types.ObjectType{AttrTypes: latestVersionAttrTypes}
+
+	elems := make(map[string]attr.Value, len(subjects))
+	for _, subject := range subjects {
+		schema := latest[subject]
+		obj, d := types.ObjectValue(latestVersionAttrTypes, map[string]attr.Value{
+			"version":     types.Int64Value(int64(schema.Version)),
+			"schema_id":   types.Int64Value(int64(schema.ID)),
+			"schema_type": types.StringValue(schema.Type()),
+		})
+		diags.Append(d...)
+		elems[subject] = obj
+	}
+	if diags.HasError() {
+		return types.MapNull(objectType), diags
+	}
+
+	out, d := types.MapValue(objectType, elems)
+	diags.Append(d...)
+	return out, diags
+}
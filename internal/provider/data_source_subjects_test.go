@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSubjectsDataSource_basic(t *testing.T) {
+	prefix := acctest.RandomWithPrefix("tf-acc-test-subjects")
+	datasourceName := "data.schemaregistry_subjects.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSubjectsDataSourceConfig(prefix),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "subjects.#", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "subjects.0", prefix+"-value"),
+					resource.TestCheckResourceAttr(datasourceName, "latest.%", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "latest."+prefix+"-value.version", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "latest."+prefix+"-value.schema_type", "AVRO"),
+					resource.TestCheckResourceAttrPair(datasourceName, "latest."+prefix+"-value.schema_id",
+						"schemaregistry_schema.value", "schema_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSubjectsDataSourceConfig(prefix string) string {
+	const template = `
+resource "schemaregistry_schema" "key" {
+  subject     = "%[1]s-key"
+  schema_type = "JSON"
+  schema      = jsonencode({ "type" : "string" })
+}
+
+resource "schemaregistry_schema" "value" {
+  subject     = "%[1]s-value"
+  schema_type = "AVRO"
+  schema      = <<EOF
+%[2]s
+EOF
+}
+
+data "schemaregistry_subjects" "test" {
+  subject_prefix = "%[1]s"
+  pattern        = "-value$"
+  schema_type    = "AVRO"
+  include_latest = true
+
+  depends_on = [schemaregistry_schema.key, schemaregistry_schema.value]
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_base(),
+		fmt.Sprintf(template, prefix, initialSchema))
+}
@@ -2,20 +2,23 @@ package provider
 
 import (
 	"context"
-	"net/http"
-	"net/http/cookiejar"
+	"maps"
 	"regexp"
 	"time"
 
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/riferrei/srclient"
 )
 
 // Ensure provider satisfies various expected interfaces.
@@ -40,10 +43,54 @@ type Provider struct {
 
 // ProviderModel maps provider schema data to a Go type.
 type ProviderModel struct {
-	URL        types.String `tfsdk:"schema_registry_url"`
-	Username   types.String `tfsdk:"username"`
-	Password   types.String `tfsdk:"password"`
-	MaxRetries types.Int64  `tfsdk:"max_retries"`
+	URL                   types.String  `tfsdk:"schema_registry_url"`
+	Username              types.String  `tfsdk:"username"`
+	Password              types.String  `tfsdk:"password"`
+	RequestTimeout        types.String  `tfsdk:"request_timeout"`
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	RetryMaxWait          types.String  `tfsdk:"retry_max_wait"`
+	RetryableStatusCodes  types.List    `tfsdk:"retryable_status_codes"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	CompatibilityCheck    types.String  `tfsdk:"compatibility_check"`
+	Context               types.String  `tfsdk:"context"`
+	Normalize             types.Bool    `tfsdk:"normalize"`
+	CACert                types.String  `tfsdk:"ca_cert"`
+	CACertFile            types.String  `tfsdk:"ca_cert_file"`
+	ClientCert            types.String  `tfsdk:"client_cert"`
+	ClientKey             types.String  `tfsdk:"client_key"`
+	TLSServerName         types.String  `tfsdk:"tls_server_name"`
+	InsecureSkipVerify    types.Bool    `tfsdk:"insecure_skip_verify"`
+	BearerToken           types.String  `tfsdk:"bearer_token"`
+	OAuth                 types.Object  `tfsdk:"oauth"`
+	Headers               types.Map     `tfsdk:"headers"`
+	ProxyURL              types.String  `tfsdk:"proxy_url"`
+	NoProxy               types.String  `tfsdk:"no_proxy"`
+}
+
+// oauthModel maps the oauth provider configuration to a Go type.
+type oauthModel struct {
+	TokenURL     types.String `tfsdk:"token_url"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Scopes       types.List   `tfsdk:"scopes"`
+	Audience     types.String `tfsdk:"audience"`
+}
+
+// providerData is passed to resources and data sources through their
+// Configure methods.
+type providerData struct {
+	client *utils.Client
+	// compatibilityCheck controls how ModifyPlan reports schema changes the
+	// registry would reject.
+	compatibilityCheck string
+	// schemaContext is the default schema context for subjects that are not
+	// qualified with one.
+	schemaContext string
+	// normalize is the default for whether schemas are normalized when they
+	// are registered, looked up and checked for compatibility, or nil when it
+	// is not set.
+	normalize *bool
 }
 
 const (
@@ -51,6 +98,11 @@ const (
 	defaultTimeout           = 30 * time.Second
 	defaultMaxRetries        = 6
 	retryBaseInterval        = 100 * time.Millisecond
+	defaultRetryMaxWait      = 30 * time.Second
+
+	compatibilityCheckError    = "error"
+	compatibilityCheckWarn     = "warn"
+	compatibilityCheckDisabled = "disabled"
 )
 
 var schemaRegistryURLRegex = regexp.MustCompile(schemaRegistryURLPattern)
@@ -65,31 +117,245 @@ func (p *Provider) Metadata(ctx context.Context, req provider.MetadataRequest,
 // Schema defines the provider-level schema for configuration data.
 func (p *Provider) Schema(ctx context.Context, req provider.SchemaRequest,
 	resp *provider.SchemaResponse) {
+	attributes := map[string]schema.Attribute{}
+	for _, group := range []map[string]schema.Attribute{
+		connectionAttributes(),
+		authAttributes(),
+		tlsAttributes(),
+		retryAttributes(),
+		defaultAttributes(),
+	} {
+		maps.Copy(attributes, group)
+	}
+
 	resp.Schema = schema.Schema{
-		Attributes: map[string]schema.Attribute{
-			"schema_registry_url": schema.StringAttribute{
-				Description: "URI for Schema Registry API. May use SCHEMA_REGISTRY_URL environment variable.",
-				Required:    true,
-				Validators: []validator.String{
-					stringvalidator.RegexMatches(schemaRegistryURLRegex,
-						"Schema Registry URL must start with http or https",
-					),
+		Attributes: attributes,
+	}
+}
+
+// connectionAttributes returns the attributes that locate Schema Registry API
+// and shape the requests sent to it.
+func connectionAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"schema_registry_url": schema.StringAttribute{
+			Description: "URI for Schema Registry API. May use SCHEMA_REGISTRY_URL environment variable.",
+			Required:    true,
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(schemaRegistryURLRegex,
+					"Schema Registry URL must start with http or https",
+				),
+			},
+		},
+		"request_timeout": schema.StringAttribute{
+			Description: "Maximum time a request to Schema Registry API may take, including its retries, " +
+				"e.g. `60s`. Defaults to `30s`.",
+			Optional: true,
+			Validators: []validator.String{
+				durationValidator{},
+			},
+		},
+		"headers": schema.MapAttribute{
+			Description: "HTTP headers sent with every request to Schema Registry API, e.g. tenant headers " +
+				"required by a gateway. May use SCHEMA_REGISTRY_HEADERS environment variable, as comma " +
+				"separated `name=value` pairs.",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"proxy_url": schema.StringAttribute{
+			Description: "URL of the HTTP proxy used to reach Schema Registry API. May use " +
+				"SCHEMA_REGISTRY_PROXY_URL environment variable. Defaults to the HTTP_PROXY and HTTPS_PROXY " +
+				"environment variables.",
+			Optional: true,
+		},
+		"no_proxy": schema.StringAttribute{
+			Description: "Comma separated hosts, domains and IP ranges that are reached without the proxy. " +
+				"May use SCHEMA_REGISTRY_NO_PROXY environment variable. Defaults to the NO_PROXY environment " +
+				"variable.",
+			Optional: true,
+		},
+	}
+}
+
+// authAttributes returns the attributes that authenticate requests to Schema Registry API.
+func authAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"username": schema.StringAttribute{
+			Description: "Username for Schema Registry API. May use SCHEMA_REGISTRY_USERNAME environment variable.",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRoot("bearer_token"), path.MatchRoot("oauth")),
+			},
+		},
+		"password": schema.StringAttribute{
+			Description: "Password for Schema Registry API. May use SCHEMA_REGISTRY_PASSWORD environment variable.",
+			Optional:    true,
+			Sensitive:   true,
+		},
+		"bearer_token": schema.StringAttribute{
+			Description: "Bearer token for Schema Registry API. May use SCHEMA_REGISTRY_BEARER_TOKEN " +
+				"environment variable.",
+			Optional:  true,
+			Sensitive: true,
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRoot("oauth")),
+			},
+		},
+		"oauth": schema.SingleNestedAttribute{
+			Description: "OAuth 2.0 client credentials used to fetch bearer tokens for Schema Registry API. " +
+				"Tokens are refreshed before they expire, after 5 minutes when the token endpoint does not say when " +
+				"they expire, and when the registry rejects them.",
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"token_url": schema.StringAttribute{
+					Description: "URL of the token endpoint.",
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(schemaRegistryURLRegex, "Token URL must start with http or https"),
+					},
 				},
+				"client_id": schema.StringAttribute{
+					Description: "The client ID.",
+					Required:    true,
+				},
+				"client_secret": schema.StringAttribute{
+					Description: "The client secret.",
+					Required:    true,
+					Sensitive:   true,
+				},
+				"scopes": schema.ListAttribute{
+					Description: "Scopes to request.",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+				"audience": schema.StringAttribute{
+					Description: "Audience to request the token for, as required by some identity providers.",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+// tlsAttributes returns the attributes that configure TLS connections to Schema Registry API.
+func tlsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"ca_cert": schema.StringAttribute{
+			Description: "PEM encoded CA certificates used to verify the Schema Registry. May use " +
+				"SCHEMA_REGISTRY_CA_CERT environment variable. Defaults to the system CA certificates.",
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
 			},
-			"username": schema.StringAttribute{
-				Description: "Username for Schema Registry API. May use SCHEMA_REGISTRY_USERNAME environment variable.",
-				Optional:    true,
+		},
+		"ca_cert_file": schema.StringAttribute{
+			Description: "Path of a file with PEM encoded CA certificates used to verify the Schema Registry. " +
+				"May use SCHEMA_REGISTRY_CA_CERT_FILE environment variable.",
+			Optional: true,
+		},
+		"client_cert": schema.StringAttribute{
+			Description: "PEM encoded client certificate, or the path of a file holding it, for mutual TLS. " +
+				"Requires `client_key`. May use SCHEMA_REGISTRY_CLIENT_CERT environment variable.",
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
 			},
-			"password": schema.StringAttribute{
-				Description: "Password for Schema Registry API. May use SCHEMA_REGISTRY_PASSWORD environment variable.",
-				Optional:    true,
-				Sensitive:   true,
+		},
+		"client_key": schema.StringAttribute{
+			Description: "PEM encoded client private key, or the path of a file holding it, for mutual TLS. " +
+				"Requires `client_cert`. May use SCHEMA_REGISTRY_CLIENT_KEY environment variable.",
+			Optional:  true,
+			Sensitive: true,
+			Validators: []validator.String{
+				stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
 			},
-			"max_retries": schema.Int64Attribute{
-				Description: "Maximum number of retry attempts for GetSchema calls using exponential backoff. Defaults to 6.",
-				Optional:    true,
+		},
+		"tls_server_name": schema.StringAttribute{
+			Description: "Host name the Schema Registry certificate is verified against, when it differs from " +
+				"the URL host. May use SCHEMA_REGISTRY_TLS_SERVER_NAME environment variable.",
+			Optional: true,
+		},
+		"insecure_skip_verify": schema.BoolAttribute{
+			Description: "Whether to skip verification of the Schema Registry certificate. Only use this for " +
+				"testing. May use SCHEMA_REGISTRY_INSECURE_SKIP_VERIFY environment variable. Defaults to false.",
+			Optional: true,
+		},
+	}
+}
+
+// retryAttributes returns the attributes that configure retries and client-side limits.
+func retryAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"max_retries": schema.Int64Attribute{
+			Description: "Maximum number of retry attempts for failed requests, using jittered exponential " +
+				"backoff. Requests that are not idempotent are only retried when the registry cannot have " +
+				"processed them. Defaults to 6.",
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		},
+		"retry_max_wait": schema.StringAttribute{
+			Description: "Maximum time to wait between two attempts, including waits requested by the registry " +
+				"with a `Retry-After` header, e.g. `30s`. Defaults to `30s`.",
+			Optional: true,
+			Validators: []validator.String{
+				durationValidator{},
+			},
+		},
+		"retryable_status_codes": schema.ListAttribute{
+			Description: "HTTP status codes of responses that are retried. Defaults to `[429, 502, 503, 504]`.",
+			Optional:    true,
+			ElementType: types.Int64Type,
+			Validators: []validator.List{
+				listvalidator.ValueInt64sAre(int64validator.Between(100, 599)),
+			},
+		},
+		"requests_per_second": schema.Float64Attribute{
+			Description: "Maximum average number of requests per second sent to Schema Registry API, shared " +
+				"by all resources and data sources, in bursts of up to one second's worth of requests. " +
+				"Defaults to no limit.",
+			Optional: true,
+			Validators: []validator.Float64{
+				float64validator.AtLeast(0.01),
 			},
 		},
+		"max_concurrent_requests": schema.Int64Attribute{
+			Description: "Maximum number of requests to Schema Registry API in flight at once, shared by all " +
+				"resources and data sources. Defaults to no limit.",
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+	}
+}
+
+// defaultAttributes returns the attributes that set defaults for resources and data sources.
+func defaultAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"compatibility_check": schema.StringAttribute{
+			Description: "How schema changes that the subject's compatibility level would reject are reported " +
+				"during plan: `error`, `warn` or `disabled`. May be overridden per resource. Defaults to `error`.",
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(compatibilityCheckError, compatibilityCheckWarn, compatibilityCheckDisabled),
+			},
+		},
+		"context": schema.StringAttribute{
+			Description: "Default schema context, e.g. `.team-a`, for subjects that are not qualified with one. " +
+				"May be overridden per resource. May use SCHEMA_REGISTRY_CONTEXT environment variable. " +
+				"Defaults to the default context.",
+			Optional: true,
+			Validators: []validator.String{
+				contextNameValidator(),
+			},
+		},
+		"normalize": schema.BoolAttribute{
+			Description: "Whether schemas are normalized when they are registered, looked up and checked for " +
+				"compatibility. May be overridden per resource. When not set, schemas are registered and checked " +
+				"without normalization but looked up with it to detect changes that only reformat the schema.",
+			Optional: true,
+		},
 	}
 }
 
@@ -108,6 +374,7 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 	url := getEnvOrDefault("SCHEMA_REGISTRY_URL", config.URL.ValueString())
 	username := getEnvOrDefault("SCHEMA_REGISTRY_USERNAME", config.Username.ValueString())
 	password := getEnvOrDefault("SCHEMA_REGISTRY_PASSWORD", config.Password.ValueString())
+	schemaContext := getEnvOrDefault("SCHEMA_REGISTRY_CONTEXT", config.Context.ValueString())
 
 	ctx = tflog.SetField(ctx, "schema_registry_url", url)
 	ctx = tflog.SetField(ctx, "username", username)
@@ -121,25 +388,17 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
-	// Build HTTP client with cookie jar for LB sticky sessions
-	jar, err := cookiejar.New(nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to create client", err.Error())
+	httpClient, maxRetries, diags := buildHTTPClient(ctx, config, username != "" || password != "")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	httpClient := &http.Client{
-		Timeout: defaultTimeout,
-		Jar:     jar,
-	}
 
 	// Create Schema Registry client with custom HTTP client
-	client := srclient.NewSchemaRegistryClient(url, srclient.WithClient(httpClient))
+	client := utils.NewClient(url, httpClient)
 
-	// Set retry delays for GetSchema calls using exponential backoff
-	maxRetries := defaultMaxRetries
-	if !config.MaxRetries.IsNull() {
-		maxRetries = int(config.MaxRetries.ValueInt64())
-	}
+	// Retry lookups of schemas that were just registered, which may not have
+	// reached every registry instance yet
 	client.SetRetryDelays(buildRetryDelays(maxRetries, retryBaseInterval))
 
 	if username != "" && password != "" {
@@ -150,9 +409,20 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
+	compatibilityCheck := compatibilityCheckError
+	if !config.CompatibilityCheck.IsNull() {
+		compatibilityCheck = config.CompatibilityCheck.ValueString()
+	}
+
 	// Make the client available during DataSource and Resource type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	data := &providerData{
+		client:             client,
+		compatibilityCheck: compatibilityCheck,
+		schemaContext:      schemaContext,
+		normalize:          config.Normalize.ValueBoolPointer(),
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
 
 	tflog.Info(ctx, "Configured Schema Registry client", map[string]any{"success": true})
 }
@@ -161,6 +431,9 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewSchemaResource,
+		NewSubjectConfigResource,
+		NewGlobalConfigResource,
+		NewModeResource,
 	}
 }
 
@@ -168,5 +441,13 @@ func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
 func (p *Provider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewSchemaDataSource,
+		NewGlobalConfigDataSource,
+		NewModeDataSource,
+		NewSubjectsDataSource,
+		NewSchemaByIDDataSource,
+		NewSubjectVersionsDataSource,
+		NewSchemaReferencedByDataSource,
+		NewCompatibilityCheckDataSource,
+		NewContextsDataSource,
 	}
 }
@@ -2,13 +2,23 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	tfprotov6 "github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/riferrei/srclient"
 	"github.com/testcontainers/testcontainers-go/modules/redpanda"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/fakeregistry"
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
 )
 
 const (
@@ -22,6 +32,12 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 }
 
 func TestMain(m *testing.M) {
+	// Only acceptance tests need a registry container; unit tests run against
+	// the in-memory registry in the fakeregistry package
+	if os.Getenv("TF_ACC") == "" {
+		os.Exit(m.Run())
+	}
+
 	ctx := context.Background()
 
 	redpandaContainer, err := redpanda.Run(ctx,
@@ -58,3 +74,83 @@ func TestMain(m *testing.M) {
 
 	os.Exit(tests)
 }
+
+// testAccUnsupportedFeatures lists the features the Redpanda image used by
+// acceptance tests does not support, and why. Their acceptance tests are
+// skipped and the features are covered by unit tests against the in-memory
+// registry instead.
+var testAccUnsupportedFeatures = map[string]string{
+	"contexts": "Redpanda v24.1 does not support schema contexts",
+}
+
+// testAccPreCheckFeature skips an acceptance test of a feature the
+// acceptance test registry does not support.
+func testAccPreCheckFeature(t *testing.T, feature string) {
+	t.Helper()
+
+	if reason, ok := testAccUnsupportedFeatures[feature]; ok {
+		t.Skipf("%s is not supported by %s: %s", feature, redpandaContainerImage, reason)
+	}
+}
+
+// testAccClient returns a Schema Registry client used to make out-of-band
+// changes to the registry during acceptance tests.
+func testAccClient() *srclient.SchemaRegistryClient {
+	client := srclient.NewSchemaRegistryClient(os.Getenv("SCHEMA_REGISTRY_URL"))
+	client.CachingEnabled(false)
+	client.SetCredentials(os.Getenv("SCHEMA_REGISTRY_USERNAME"), os.Getenv("SCHEMA_REGISTRY_PASSWORD"))
+	return client
+}
+
+// testUnitClient returns an in-memory registry and a client for it that only
+// briefly waits for schemas to be read back.
+func testUnitClient(t *testing.T) (*fakeregistry.Registry, *utils.Client) {
+	t.Helper()
+
+	reg := fakeregistry.New(t)
+	client := utils.NewClient(reg.URL, reg.Client())
+	client.SetRetryDelays([]time.Duration{time.Millisecond})
+	return reg, client
+}
+
+// testUnitDataSourceRead configures d with data and reads it with a config
+// that sets the given attributes and leaves the others null.
+func testUnitDataSourceRead(t *testing.T, d datasource.DataSourceWithConfigure, data *providerData,
+	attrs map[string]tftypes.Value) datasource.ReadResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	var configureResp datasource.ConfigureResponse
+	d.Configure(ctx, datasource.ConfigureRequest{ProviderData: data}, &configureResp)
+	testUnitNoErrors(t, "Configure", configureResp.Diagnostics)
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	testUnitNoErrors(t, "Schema", schemaResp.Diagnostics)
+	s := schemaResp.Schema
+
+	objectType := s.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+		if value, ok := attrs[name]; ok {
+			values[name] = value
+		}
+	}
+	config := tfsdk.Config{Schema: s, Raw: tftypes.NewValue(objectType, values)}
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+	return resp
+}
+
+// testSubjectSoftDeleted reports whether a subject is listed when deleted
+// subjects are included, i.e. it was soft deleted rather than removed.
+func testSubjectSoftDeleted(ctx context.Context, client *utils.Client, subject string) (bool, error) {
+	subjects, err := client.ListSubjects(ctx, subject, true)
+	if err != nil {
+		return false, fmt.Errorf("error listing deleted subjects: %w", err)
+	}
+
+	return slices.Contains(subjects, subject), nil
+}
@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// globalConfigID is the ID of the singleton global config resource.
+	globalConfigID = "global"
+	// defaultCompatibilityLevel is the compatibility level of a registry
+	// that has never been configured.
+	defaultCompatibilityLevel = "BACKWARD"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &globalConfigResource{}
+	_ resource.ResourceWithConfigure   = &globalConfigResource{}
+	_ resource.ResourceWithImportState = &globalConfigResource{}
+)
+
+// NewGlobalConfigResource is a helper function to simplify the provider implementation.
+func NewGlobalConfigResource() resource.Resource {
+	return &globalConfigResource{}
+}
+
+// globalConfigResource is the resource implementation.
+type globalConfigResource struct {
+	client *utils.Client
+}
+
+// globalConfigModel describes the resource and data source data model.
+type globalConfigModel struct {
+	ID                 types.String `tfsdk:"id"`
+	CompatibilityLevel types.String `tfsdk:"compatibility_level"`
+	Normalize          types.Bool   `tfsdk:"normalize"`
+}
+
+// Metadata returns the resource type name.
+func (r *globalConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_global_config"
+}
+
+// Schema defines the schema for the resource.
+func (r *globalConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Global config resource. Manages the registry-wide configuration that subjects " +
+			"without a config of their own inherit. Only one instance should exist per registry. " +
+			"Destroying the resource restores the registry defaults.",
+		Description: "Manages the global configuration of the Schema Registry.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always `global`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"compatibility_level": schema.StringAttribute{
+				Description: "The global compatibility level. Left unchanged when not set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"NONE",
+						"BACKWARD",
+						"BACKWARD_TRANSITIVE",
+						"FORWARD",
+						"FORWARD_TRANSITIVE",
+						"FULL",
+						"FULL_TRANSITIVE",
+					),
+				},
+			},
+			"normalize": schema.BoolAttribute{
+				Description: "Whether schemas are normalized by default. Left unchanged when not set.",
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *globalConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *globalConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan globalConfigModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &plan); err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Creating Global Config", "Could not set global config", err)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *globalConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state globalConfigModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := readGlobalConfig(ctx, r.client, &state); err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Reading Global Config", "Could not read global config", err)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *globalConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan globalConfigModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &plan); err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Updating Global Config", "Could not set global config", err)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *globalConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	err := r.client.DeleteConfig(ctx, "")
+	if isUnsupportedEndpoint(err) {
+		// Older registries cannot delete the global config, so set the
+		// defaults explicitly instead
+		tflog.Debug(ctx, "DELETE /config is not supported, restoring the default compatibility level")
+		normalize := false
+		err = r.client.UpdateConfig(ctx, "", &utils.Config{
+			CompatibilityLevel: defaultCompatibilityLevel,
+			Normalize:          &normalize,
+		})
+	}
+	if err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Deleting Global Config", "Could not restore the global config", err)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *globalConfigResource) ImportState(ctx context.Context, _ resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), globalConfigID)...)
+}
+
+// apply sends the planned settings to the registry and reads back the
+// effective configuration so computed values are known.
+func (r *globalConfigResource) apply(ctx context.Context, plan *globalConfigModel) error {
+	config := &utils.Config{}
+	if !plan.CompatibilityLevel.IsUnknown() {
+		config.CompatibilityLevel = plan.CompatibilityLevel.ValueString()
+	}
+	if !plan.Normalize.IsUnknown() {
+		config.Normalize = plan.Normalize.ValueBoolPointer()
+	}
+
+	if config.CompatibilityLevel != "" || config.Normalize != nil {
+		if err := r.client.UpdateConfig(ctx, "", config); err != nil {
+			return err
+		}
+	}
+
+	return readGlobalConfig(ctx, r.client, plan)
+}
+
+// readGlobalConfig populates model from the registry's global configuration.
+func readGlobalConfig(ctx context.Context, client *utils.Client, model *globalConfigModel) error {
+	config, err := client.GetConfig(ctx, "", false)
+	if err != nil {
+		return err
+	}
+
+	model.ID = types.StringValue(globalConfigID)
+	model.CompatibilityLevel = types.StringValue(config.CompatibilityLevel)
+	model.Normalize = types.BoolValue(config.Normalize != nil && *config.Normalize)
+
+	return nil
+}
+
+// isUnsupportedEndpoint reports whether err means the registry does not
+// implement the requested endpoint or method.
+func isUnsupportedEndpoint(err error) bool {
+	var regErr *utils.RegistryError
+	if !errors.As(err, &regErr) {
+		return false
+	}
+	return regErr.ErrorCode == 0 &&
+		(regErr.StatusCode == http.StatusNotFound || regErr.StatusCode == http.StatusMethodNotAllowed)
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// The global config is shared by every subject, so these tests must not run
+// in parallel with the others.
+func TestAccGlobalConfigResource_basic(t *testing.T) {
+	resourceName := "schemaregistry_global_config.test"
+	dataSourceName := "data.schemaregistry_global_config.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGlobalConfigDestroy,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccGlobalConfigResourceConfig("FULL"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "global"),
+					resource.TestCheckResourceAttr(resourceName, "compatibility_level", "FULL"),
+					resource.TestCheckResourceAttr(dataSourceName, "compatibility_level", "FULL"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccGlobalConfigResourceConfig("NONE"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "compatibility_level", "NONE"),
+					resource.TestCheckResourceAttr(dataSourceName, "compatibility_level", "NONE"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "global",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckGlobalConfigDestroy verifies the registry default is restored
+// once the resource is destroyed.
+func testAccCheckGlobalConfigDestroy(_ *terraform.State) error {
+	level, err := testAccClient().GetGlobalCompatibilityLevel()
+	if err != nil {
+		return err
+	}
+	if level.String() != defaultCompatibilityLevel {
+		return fmt.Errorf("expected global compatibility level %s, got %s", defaultCompatibilityLevel, level.String())
+	}
+	return nil
+}
+
+func testAccGlobalConfigResourceConfig(compatibilityLevel string) string {
+	const template = `
+resource "schemaregistry_global_config" "test" {
+  compatibility_level = "%s"
+}
+
+data "schemaregistry_global_config" "test" {
+  depends_on = [schemaregistry_global_config.test]
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_base(),
+		fmt.Sprintf(template, compatibilityLevel),
+	)
+}
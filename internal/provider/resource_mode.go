@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &modeResource{}
+	_ resource.ResourceWithConfigure   = &modeResource{}
+	_ resource.ResourceWithImportState = &modeResource{}
+)
+
+// NewModeResource is a helper function to simplify the provider implementation.
+func NewModeResource() resource.Resource {
+	return &modeResource{}
+}
+
+// modeResource is the resource implementation.
+type modeResource struct {
+	client        *utils.Client
+	schemaContext string
+}
+
+// modeResourceModel describes the resource data model.
+type modeResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Subject types.String `tfsdk:"subject"`
+	Context types.String `tfsdk:"context"`
+	Mode    types.String `tfsdk:"mode"`
+	Force   types.Bool   `tfsdk:"force"`
+}
+
+// Metadata returns the resource type name.
+func (r *modeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mode"
+}
+
+// Schema defines the schema for the resource.
+func (r *modeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Mode resource. Manages the mode of a subject, or of the whole registry when " +
+			"`subject` is not set. Destroying the resource reverts a subject to the global mode, and the " +
+			"global mode to `READWRITE`.",
+		Description: "Manages the mode of a subject or of the Schema Registry.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The subject, qualified with its schema context when it is not the default context, " +
+					"or `global` for the registry mode.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subject": schema.StringAttribute{
+				Description: "The subject to set the mode of. The registry mode is managed when not set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"context": schema.StringAttribute{
+				Description: "The schema context of the subject, e.g. `.team-a`. Without a subject, the mode of " +
+					"the context itself is managed. Subjects that are already qualified with a context are used as " +
+					"given. Defaults to the provider `context` when a subject is set.",
+				Optional: true,
+				Validators: []validator.String{
+					contextNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mode": schema.StringAttribute{
+				Description: "The mode: `READWRITE`, `READONLY`, `READONLY_OVERRIDE` or `IMPORT`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						utils.ModeReadWrite,
+						utils.ModeReadOnly,
+						utils.ModeReadOnlyOverride,
+						utils.ModeImport,
+					),
+				},
+			},
+			"force": schema.BoolAttribute{
+				Description: "Whether to switch to IMPORT mode even though schemas are already registered.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *modeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.schemaContext = data.schemaContext
+}
+
+func (r *modeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan modeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subject := r.qualifiedSubject(plan)
+	if err := r.client.UpdateMode(ctx, subject, plan.Mode.ValueString(), plan.Force.ValueBool()); err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Creating Mode", fmt.Sprintf("Could not set mode of %s", modeTarget(subject)), err)
+		return
+	}
+
+	plan.ID = types.StringValue(modeID(subject))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *modeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state modeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subject := r.qualifiedSubject(state)
+	mode, err := r.client.GetMode(ctx, subject, false)
+	if err != nil {
+		if subject != "" && (utils.IsNotFound(err) || errors.Is(err, utils.ErrModeNotFound)) {
+			tflog.Warn(ctx, "Subject mode not found in Schema Registry, removing from state", map[string]interface{}{
+				"subject": subject,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addRegistryError(&resp.Diagnostics, "Error Reading Mode", fmt.Sprintf("Could not read mode of %s", modeTarget(subject)), err)
+		return
+	}
+
+	state.ID = types.StringValue(modeID(subject))
+	state.Mode = types.StringValue(mode)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *modeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan modeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subject := r.qualifiedSubject(plan)
+	if err := r.client.UpdateMode(ctx, subject, plan.Mode.ValueString(), plan.Force.ValueBool()); err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Updating Mode", fmt.Sprintf("Could not set mode of %s", modeTarget(subject)), err)
+		return
+	}
+
+	plan.ID = types.StringValue(modeID(subject))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *modeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state modeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The global mode cannot be deleted, so it is reset to the registry default
+	subject := r.qualifiedSubject(state)
+	var err error
+	if subject == "" {
+		err = r.client.UpdateMode(ctx, "", utils.ModeReadWrite, false)
+	} else {
+		err = r.client.DeleteMode(ctx, subject)
+		if utils.IsNotFound(err) || errors.Is(err, utils.ErrModeNotFound) {
+			err = nil
+		}
+	}
+	if err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Deleting Mode", fmt.Sprintf("Could not reset mode of %s", modeTarget(subject)), err)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState imports the mode of a subject, or the registry mode when the
+// ID is `global`.
+func (r *modeResource) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+	if req.ID != globalConfigID {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subject"), req.ID)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force"), false)...)
+}
+
+// qualifiedSubject returns the subject of m qualified with its schema
+// context, as used in registry requests. Without a subject, only a
+// configured context applies, so that the registry mode is managed by
+// default.
+func (r *modeResource) qualifiedSubject(m modeResourceModel) string {
+	if m.Subject.IsNull() {
+		return qualifySubject(m.Context, "", "")
+	}
+	return qualifySubject(m.Context, r.schemaContext, m.Subject.ValueString())
+}
+
+// modeID returns the resource ID for the mode of subject.
+func modeID(subject string) string {
+	if subject == "" {
+		return globalConfigID
+	}
+	return subject
+}
+
+// modeTarget describes the owner of a mode in diagnostics.
+func modeTarget(subject string) string {
+	if subject == "" {
+		return "the registry"
+	}
+	return "subject " + subject
+}
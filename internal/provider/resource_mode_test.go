@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccModeResource_subject(t *testing.T) {
+	subjectName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "schemaregistry_mode.test"
+	dataSourceName := "data.schemaregistry_mode.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccModeResourceConfig(subjectName, "READONLY"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", subjectName),
+					resource.TestCheckResourceAttr(resourceName, "subject", subjectName),
+					resource.TestCheckResourceAttr(resourceName, "mode", "READONLY"),
+					resource.TestCheckResourceAttr(resourceName, "force", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "mode", "READONLY"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccModeResourceConfig(subjectName, "READWRITE"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "mode", "READWRITE"),
+					resource.TestCheckResourceAttr(dataSourceName, "mode", "READWRITE"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     subjectName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccModeResourceConfig(subject, mode string) string {
+	const template = `
+resource "schemaregistry_mode" "test" {
+  subject = "%s"
+  mode    = "%s"
+}
+
+data "schemaregistry_mode" "test" {
+  subject = schemaregistry_mode.test.subject
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_base(),
+		fmt.Sprintf(template, subject, mode),
+	)
+}
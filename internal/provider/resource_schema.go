@@ -2,17 +2,24 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
-	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -28,6 +35,18 @@ var (
 	_ resource.ResourceWithModifyPlan  = &schemaResource{}
 )
 
+// defaultSchemaTimeout is how long each operation on a schema may take when
+// the timeouts block does not set it.
+const defaultSchemaTimeout = 20 * time.Minute
+
+// timeoutsAttrTypes are the attribute types of the timeouts block.
+var timeoutsAttrTypes = map[string]attr.Type{
+	"create": types.StringType,
+	"read":   types.StringType,
+	"update": types.StringType,
+	"delete": types.StringType,
+}
+
 // NewSchemaResource is a helper function to simplify the provider implementation.
 func NewSchemaResource() resource.Resource {
 	return &schemaResource{}
@@ -35,20 +54,32 @@ func NewSchemaResource() resource.Resource {
 
 // schemaResource is the resource implementation.
 type schemaResource struct {
-	client *srclient.SchemaRegistryClient
+	client             *utils.Client
+	compatibilityCheck string
+	schemaContext      string
+	normalize          *bool
 }
 
 // schemaResourceModel describes the resource data model.
 type schemaResourceModel struct {
-	ID                 types.String         `tfsdk:"id"`
-	Subject            types.String         `tfsdk:"subject"`
-	Schema             jsontypes.Normalized `tfsdk:"schema"`
-	SchemaID           types.Int64          `tfsdk:"schema_id"`
-	SchemaType         types.String         `tfsdk:"schema_type"`
-	Version            types.Int64          `tfsdk:"version"`
-	Reference          types.List           `tfsdk:"references"`
-	CompatibilityLevel types.String         `tfsdk:"compatibility_level"`
-	HardDelete         types.Bool           `tfsdk:"hard_delete"`
+	ID                        types.String   `tfsdk:"id"`
+	Subject                   types.String   `tfsdk:"subject"`
+	Context                   types.String   `tfsdk:"context"`
+	Schema                    types.String   `tfsdk:"schema"`
+	RegisteredSchema          types.String   `tfsdk:"registered_schema"`
+	SchemaID                  types.Int64    `tfsdk:"schema_id"`
+	SchemaType                types.String   `tfsdk:"schema_type"`
+	Version                   types.Int64    `tfsdk:"version"`
+	Reference                 types.List     `tfsdk:"references"`
+	Metadata                  types.Object   `tfsdk:"metadata"`
+	RuleSet                   types.Object   `tfsdk:"rule_set"`
+	CompatibilityLevel        types.String   `tfsdk:"compatibility_level"`
+	CompatibilityCheck        types.String   `tfsdk:"compatibility_check"`
+	HardDelete                types.Bool     `tfsdk:"hard_delete"`
+	PreventDeleteIfReferenced types.Bool     `tfsdk:"prevent_delete_if_referenced"`
+	SchemaIDStability         types.String   `tfsdk:"schema_id_stability"`
+	Normalize                 types.Bool     `tfsdk:"normalize"`
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -57,45 +88,62 @@ func (r *schemaResource) Metadata(_ context.Context, req resource.MetadataReques
 }
 
 // Schema defines the schema for the resource.
-func (r *schemaResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *schemaResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Schema resource. Manages a schema in the Schema Registry.",
 		Description:         "Manages a schema in the Schema Registry.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "The globally unique ID of the schema.",
+				Description: "The subject, qualified with its schema context when it is not the default context.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"subject": schema.StringAttribute{
-				Description: "The subject related to the schema.",
-				Required:    true,
+				Description: "The subject related to the schema. May be qualified with a schema context, " +
+					"e.g. `:.team-a:orders-value`.",
+				Required: true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 					stringvalidator.LengthAtMost(249),
-					stringvalidator.RegexMatches(
-						regexp.MustCompile(`^[A-Za-z0-9._-]+$`),
-						"May only contain letters, digits, dots ('.'), underscores ('_') or hyphens ('-')",
-					)},
+					subjectNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"context": schema.StringAttribute{
+				Description: contextDescription,
+				Optional:    true,
+				Validators: []validator.String{
+					contextNameValidator(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"schema": schema.StringAttribute{
-				Description: "The schema definition.",
-				Required:    true,
-				CustomType:  jsontypes.NormalizedType{},
+				Description: "The schema definition. AVRO and JSON schemas are compared as JSON documents; " +
+					"PROTOBUF schemas are compared ignoring whitespace, comments and declaration order. State keeps " +
+					"the configured form; see `registered_schema` for the form stored by the registry.",
+				Required: true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(2),
 				},
 			},
-			"schema_id": schema.Int64Attribute{
-				Description: "The ID of the schema.",
+			"registered_schema": schema.StringAttribute{
+				Description: "The schema as stored by the registry, e.g. normalized when `normalize` is set.",
 				Computed:    true,
 			},
+			"schema_id": schema.Int64Attribute{
+				Description: "The ID of the schema. May only be set while the subject or registry is in IMPORT " +
+					"mode, to register the schema under a given ID. Planning fails if the ID is used by a " +
+					"different schema.",
+				Optional: true,
+				Computed: true,
+			},
 			"schema_type": schema.StringAttribute{
 				Description: "The schema format.",
 				Required:    true,
@@ -111,8 +159,11 @@ func (r *schemaResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"version": schema.Int64Attribute{
-				Description: "The version of the schema.",
-				Computed:    true,
+				Description: "The version of the schema. May only be set while the subject or registry is in " +
+					"IMPORT mode, to register the schema under a given version. Planning fails if the version is " +
+					"used by a different schema, or if the schema changes while the version is left unchanged.",
+				Optional: true,
+				Computed: true,
 			},
 			"references": schema.ListNestedAttribute{
 				Description: "The referenced schema list.",
@@ -143,6 +194,12 @@ func (r *schemaResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					},
 				},
 			},
+			"metadata": metadataAttribute(
+				"Data contract metadata of the schema. Changing or removing it registers a new version. When " +
+					"omitted, the schema has no metadata of its own."),
+			"rule_set": ruleSetAttribute(
+				"Data contract rules of the schema. Changing or removing them registers a new version. When " +
+					"omitted, the schema has no rules of its own."),
 			"compatibility_level": schema.StringAttribute{
 				Description: "The compatibility level of the schema.",
 				Optional:    true,
@@ -162,12 +219,52 @@ func (r *schemaResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					),
 				},
 			},
+			"compatibility_check": schema.StringAttribute{
+				Description: "How schema changes that the subject's compatibility level would reject are reported " +
+					"during plan: `error`, `warn` or `disabled`. Defaults to the provider setting.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(compatibilityCheckError, compatibilityCheckWarn, compatibilityCheckDisabled),
+				},
+			},
 			"hard_delete": schema.BoolAttribute{
 				Description: "Controls whether a schema should be soft or hard deleted.",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"prevent_delete_if_referenced": schema.BoolAttribute{
+				Description: "Whether plans that destroy the schema, and the deletion itself, fail while other " +
+					"schemas reference any version of the subject. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"normalize": schema.BoolAttribute{
+				Description: "Whether the schema is normalized when it is registered, looked up and checked for " +
+					"compatibility. Defaults to the provider setting. When neither is set, the schema is registered " +
+					"and checked without normalization but looked up with it to detect changes.",
+				Optional: true,
+			},
+			"schema_id_stability": schema.StringAttribute{
+				Description: "How plans that replace the schema, e.g. because `subject` or `schema_type` changed, " +
+					"report that the new registration would not reuse the current `schema_id`: `error`, `warn` " +
+					"or `disabled`. Defaults to `disabled`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(compatibilityCheckDisabled),
+				Validators: []validator.String{
+					stringvalidator.OneOf(compatibilityCheckError, compatibilityCheckWarn, compatibilityCheckDisabled),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -178,22 +275,41 @@ func (r *schemaResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*srclient.SchemaRegistryClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *srclient.SchemaRegistryClient, got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.compatibilityCheck = data.compatibilityCheck
+	r.schemaContext = data.schemaContext
+	r.normalize = data.normalize
 }
 
 func (r *schemaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the plan is null we assume it's a destroy operation, so don't modify
+	// plan but make sure the deletion is allowed
+	if req.Plan.Raw.IsNull() {
+		var state schemaResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if !resp.Diagnostics.HasError() {
+			r.checkReferencesBeforeDelete(ctx, state, &resp.Diagnostics)
+		}
+		return
+	}
+
+	// Explicit IDs must be checked for new resources too
+	r.checkExplicitIDs(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// If the state is null we assume it's a new resource
-	// If the plan is null we assume it's a destroy operation, so don't modify plan
-	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+	if req.State.Raw.IsNull() {
 		return
 	}
 
@@ -213,21 +329,38 @@ func (r *schemaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 		return
 	}
 
-	references, diags := utils.ToRegistryReferences(ctx, plan.Reference)
+	// Formatting changes are not changes, judged by the rules of the declared
+	// schema type
+	if !plan.Schema.IsUnknown() && plan.SchemaType.Equal(state.SchemaType) && !plan.Schema.Equal(state.Schema) &&
+		utils.SchemasEqual(plan.SchemaType.ValueString(), state.Schema.ValueString(), plan.Schema.ValueString()) {
+		plan.Schema = state.Schema
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("schema"), state.Schema)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	schemaReq, diags := r.schemaRequest(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	clearRemovedDataContract(&schemaReq, plan, state)
 
-	// Check if the schemas are semantically equivalent
-	equal, err := utils.IsSemanticallyEqual(
-		ctx,
-		r.client,
-		state.Subject.ValueString(),
-		plan.Schema.ValueString(),
-		utils.ToSchemaType(plan.SchemaType.ValueString()),
-		references,
-	)
+	// A replacement deletes the subject and registers the schema again, which
+	// may assign it a new ID
+	replace := r.requiresReplace(plan, state)
+	if replace {
+		r.checkSchemaIDStability(ctx, plan, state, schemaReq, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Check if the schemas, including their data contracts, are semantically
+	// equivalent
+	equal, err := utils.IsSemanticallyEqual(ctx, r.client, r.qualifiedSubject(state), schemaReq,
+		r.lookupNormalizes(plan))
 	if err != nil {
 		// If the schema lookup fails (e.g., subject doesn't exist yet,
 		// referenced schemas don't exist), we should not suppress the plan.
@@ -235,7 +368,7 @@ func (r *schemaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 		// only log this as debug information and continue without modifying the
 		// plan.
 		tflog.Debug(ctx, "Schema lookup failed during ModifyPlan, continuing without plan modification", map[string]interface{}{
-			"subject": state.Subject.ValueString(),
+			"subject": r.qualifiedSubject(state),
 			"error":   err.Error(),
 		})
 		return
@@ -246,11 +379,245 @@ func (r *schemaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 	// hard_delete, etc.) so that changes to these fields are properly detected
 	if equal {
 		plan.Schema = state.Schema
-		plan.SchemaID = state.SchemaID
-		plan.Version = state.Version
+		plan.RegisteredSchema = state.RegisteredSchema
+		if plan.SchemaID.IsUnknown() {
+			plan.SchemaID = state.SchemaID
+		}
+		if plan.Version.IsUnknown() {
+			plan.Version = state.Version
+		}
 		plan.Reference = state.Reference
 		resp.Plan.Set(ctx, plan) // ignore diags: we only copy known-good values
+		return
+	}
+
+	// The schema will be registered as a new version, so make sure the
+	// registry is going to accept it before anything is applied. Replacements
+	// start a new subject history and are not checked.
+	if !replace {
+		r.checkPinnedVersion(ctx, req, state, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		r.checkCompatibility(ctx, plan, schemaReq, resp)
+	}
+}
+
+// checkPinnedVersion reports a changed schema whose configured version is
+// still the version the current schema is registered under, since the new
+// schema cannot be registered under it.
+func (r *schemaResource) checkPinnedVersion(ctx context.Context, req resource.ModifyPlanRequest,
+	state schemaResourceModel, resp *resource.ModifyPlanResponse) {
+	var version types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("version"), &version)...)
+	if resp.Diagnostics.HasError() || version.IsNull() || version.IsUnknown() || !version.Equal(state.Version) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(path.Root("version"), "Version already registered",
+		fmt.Sprintf("The schema of subject %s changed, but version is still set to %d, the version the current "+
+			"schema is registered under. Set version to an unused value, or remove it to let the registry assign "+
+			"the next version.", r.qualifiedSubject(state), version.ValueInt64()))
+}
+
+// requiresReplace reports whether planning changes from state to plan
+// replace the resource.
+func (r *schemaResource) requiresReplace(plan, state schemaResourceModel) bool {
+	return r.qualifiedSubject(plan) != r.qualifiedSubject(state) || !plan.SchemaType.Equal(state.SchemaType)
+}
+
+// checkSchemaIDStability reports when replacing the resource would register
+// the planned schema under a different ID than the current schema_id, which
+// breaks clients that cache schema IDs.
+func (r *schemaResource) checkSchemaIDStability(ctx context.Context, plan, state schemaResourceModel,
+	schemaReq utils.SchemaRequest, resp *resource.ModifyPlanResponse) {
+	mode := plan.SchemaIDStability.ValueString()
+	if plan.SchemaIDStability.IsUnknown() || mode == compatibilityCheckDisabled {
+		return
+	}
+	if state.SchemaID.IsNull() || plan.Subject.IsUnknown() || plan.Context.IsUnknown() ||
+		plan.Schema.IsUnknown() || plan.SchemaType.IsUnknown() {
+		return
+	}
+
+	// An explicit schema_id is registered as configured
+	if !plan.SchemaID.IsUnknown() && !plan.SchemaID.IsNull() {
+		return
+	}
+
+	id := state.SchemaID.ValueInt64()
+	reason, err := r.schemaIDChangeReason(ctx, plan, state, schemaReq)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("schema_id_stability"), "Could not check schema ID stability",
+			fmt.Sprintf("Could not check whether replacing subject %s keeps schema ID %d: %s",
+				r.qualifiedSubject(state), id, utils.ClassifyError(err)))
+		return
+	}
+	if reason == "" {
+		return
+	}
+
+	detail := fmt.Sprintf("Replacing subject %s with %s would not reuse schema ID %d: %s.\n\nClients that cache "+
+		"schema IDs would no longer resolve the schema they expect.", r.qualifiedSubject(state),
+		r.qualifiedSubject(plan), id, reason)
+	if mode == compatibilityCheckWarn {
+		resp.Diagnostics.AddAttributeWarning(path.Root("schema_id_stability"), "Schema ID would change", detail)
+		return
+	}
+	resp.Diagnostics.AddAttributeError(path.Root("schema_id_stability"), "Schema ID would change", detail)
+}
+
+// schemaIDChangeReason explains why registering the planned schema after
+// deleting the subject in state would assign it a new ID, or returns an empty
+// string when the registry would reuse the current ID.
+func (r *schemaResource) schemaIDChangeReason(ctx context.Context, plan, state schemaResourceModel,
+	schemaReq utils.SchemaRequest) (string, error) {
+	if effectiveContext(plan.Context, r.schemaContext) != effectiveContext(state.Context, r.schemaContext) {
+		return "schema IDs are assigned separately in each schema context", nil
+	}
+
+	// The registry reuses the ID of an identical schema, so look the planned
+	// schema up under the current subject
+	subject := r.qualifiedSubject(state)
+	registered, err := r.client.LookupSchema(ctx, subject, schemaReq, r.normalizes(plan))
+	if errors.Is(utils.ClassifyError(err), utils.ErrSchemaNotFound) || utils.IsNotFound(err) {
+		return "the planned schema differs from the schema registered under that ID", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if int64(registered.ID) != state.SchemaID.ValueInt64() {
+		return fmt.Sprintf("the planned schema is registered under schema ID %d", registered.ID), nil
+	}
+
+	// A hard delete removes the schema when no other subject uses it, unless
+	// the replacement is created before the subject is destroyed
+	if !state.HardDelete.ValueBool() {
+		return "", nil
+	}
+	usages, err := r.client.GetSchemaSubjectVersions(ctx, registered.ID, subject)
+	if err != nil {
+		return "", err
+	}
+	for _, usage := range usages {
+		if usage.Subject != subject {
+			return "", nil
+		}
+	}
+	return "hard_delete removes the schema from the registry before it is registered again. Set hard_delete " +
+		"to false or use the create_before_destroy lifecycle option", nil
+}
+
+// checkExplicitIDs reports configured schema_id and version values that the
+// registry has already assigned to a different schema, since registering
+// under them would fail.
+func (r *schemaResource) checkExplicitIDs(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+	var plan schemaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var schemaID, version types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("schema_id"), &schemaID)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("version"), &version)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Subject.IsUnknown() || plan.Context.IsUnknown() || plan.Schema.IsUnknown() || plan.SchemaType.IsUnknown() {
+		return
+	}
+
+	if !schemaID.IsNull() && !schemaID.IsUnknown() {
+		id := schemaID.ValueInt64()
+		existing, err := r.client.GetSchemaByID(ctx, int(id), r.qualifiedSubject(plan))
+		reportTakenID(resp, path.Root("schema_id"), fmt.Sprintf("schema ID %d", id), plan, existing, err)
+	}
+
+	// Unless the resource is replaced, the version the current schema holds is
+	// checked against schema changes by checkPinnedVersion
+	pinned := false
+	if !req.State.Raw.IsNull() {
+		var state schemaResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		pinned = version.Equal(state.Version) && !r.requiresReplace(plan, state)
+	}
+
+	if !version.IsNull() && !version.IsUnknown() && !pinned {
+		subject := r.qualifiedSubject(plan)
+		v := strconv.FormatInt(version.ValueInt64(), 10)
+		existing, err := r.client.GetSubjectVersion(ctx, subject, v, false)
+		reportTakenID(resp, path.Root("version"), fmt.Sprintf("version %s of subject %s", v, subject), plan, existing, err)
+	}
+}
+
+// reportTakenID adds an error when existing, the schema registered under an
+// explicit ID or version, differs from the planned schema.
+func reportTakenID(resp *resource.ModifyPlanResponse, attr path.Path, what string, plan schemaResourceModel,
+	existing *utils.RegisteredSchema, err error) {
+	if err != nil {
+		if utils.IsNotFound(err) || errors.Is(utils.ClassifyError(err), utils.ErrSchemaNotFound) {
+			return
+		}
+		resp.Diagnostics.AddAttributeWarning(attr, "Could not check explicit schema ID",
+			fmt.Sprintf("Could not check whether %s is in use: %s", what, utils.ClassifyError(err)))
+		return
+	}
+
+	// Registering the same schema under the same ID again is allowed
+	schemaType := plan.SchemaType.ValueString()
+	if existing.Type() == schemaType && utils.SchemasEqual(schemaType, existing.Schema, plan.Schema.ValueString()) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(attr, "Schema ID already in use",
+		fmt.Sprintf("The Schema Registry already uses %s for a different schema, so registering the planned "+
+			"schema under it would fail. Choose an unused value, or remove the attribute to let the registry "+
+			"assign one.", what))
+}
+
+// checkCompatibility asks the registry whether the planned schema is
+// compatible with the latest version under the subject's compatibility level
+// and reports the registry's reasons if it is not.
+func (r *schemaResource) checkCompatibility(ctx context.Context, plan schemaResourceModel,
+	schemaReq utils.SchemaRequest, resp *resource.ModifyPlanResponse) {
+	mode := r.compatibilityCheck
+	if !plan.CompatibilityCheck.IsNull() && !plan.CompatibilityCheck.IsUnknown() {
+		mode = plan.CompatibilityCheck.ValueString()
+	}
+	if mode == compatibilityCheckDisabled {
+		return
+	}
+
+	subject := r.qualifiedSubject(plan)
+	result, err := r.client.CheckCompatibility(ctx, subject, "latest", schemaReq, r.normalizes(plan))
+	if err != nil {
+		// A subject without versions has nothing to be compatible with
+		if utils.IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("schema"),
+			"Could not check schema compatibility",
+			fmt.Sprintf("Could not check compatibility of the planned schema for subject %s: %s",
+				subject, utils.ClassifyError(err)),
+		)
+		return
+	}
+	if result.IsCompatible {
+		return
+	}
+
+	detail := fmt.Sprintf("The planned schema for subject %s is not compatible with the latest registered "+
+		"version under the subject's compatibility level, so registering it would fail.", subject)
+	if len(result.Messages) > 0 {
+		detail += "\n\nSchema Registry response:\n- " + strings.Join(result.Messages, "\n- ")
 	}
+
+	if mode == compatibilityCheckWarn {
+		resp.Diagnostics.AddAttributeWarning(path.Root("schema"), "Incompatible schema change", detail)
+		return
+	}
+	resp.Diagnostics.AddAttributeError(path.Root("schema"), "Incompatible schema change", detail)
 }
 
 func (r *schemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -262,70 +629,63 @@ func (r *schemaResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultSchemaTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Check if the subject is already managed in schema registry
-	subject := plan.Subject.ValueString()
-	err := utils.IsSubjectManaged(r.client, subject)
+	subject := r.qualifiedSubject(plan)
+	err := utils.IsSubjectManaged(ctx, r.client, subject)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating schema",
-			fmt.Sprintf("Error checking if subject is managed: %s", err),
-		)
+		addRegistryError(&resp.Diagnostics, "Error creating schema", "Error checking if subject is managed", err)
 		return
 	}
 
 	// Generate API request body from plan
-	schemaString := plan.Schema.ValueString()
-	schemaType := utils.ToSchemaType(plan.SchemaType.ValueString())
-	references, diags := utils.ToRegistryReferences(ctx, plan.Reference)
+	schemaReq, diags := r.schemaRequest(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Create new schema resource
-	schema, err := r.client.CreateSchema(subject, schemaString, schemaType, references...)
+	schema, err := r.registerSchema(ctx, subject, plan, schemaReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating schema",
-			"Could not create schema, unexpected error: "+err.Error(),
-		)
+		addRegistryError(&resp.Diagnostics, "Error creating schema", "Could not create schema", err)
 		return
 	}
 
 	// Set compatibility level if specified
 	if !plan.CompatibilityLevel.IsNull() && !plan.CompatibilityLevel.IsUnknown() {
-		compatibilityLevel := utils.ToCompatibilityLevelType(plan.CompatibilityLevel.ValueString())
-		_, err = r.client.ChangeSubjectCompatibilityLevel(subject, compatibilityLevel)
+		err = r.client.UpdateConfig(ctx, subject, &utils.Config{CompatibilityLevel: plan.CompatibilityLevel.ValueString()})
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error setting compatibility level",
-				"Could not set compatibility level, unexpected error: "+err.Error(),
-			)
+			addRegistryError(&resp.Diagnostics, "Error setting compatibility level",
+				"Could not set compatibility level", err)
 			return
 		}
 	} else {
 		// Fetch the current compatibility level from the server
-		compatibilityLevel, err := r.client.GetCompatibilityLevel(subject, true)
+		compatibilityLevel, err := r.client.GetCompatibilityLevel(ctx, subject)
 		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error getting compatibility level",
-				"Could not get compatibility level, unexpected error: "+err.Error(),
-			)
+			addRegistryError(&resp.Diagnostics, "Error getting compatibility level",
+				"Could not get compatibility level", err)
 			return
 		}
-		plan.CompatibilityLevel = types.StringValue(utils.FromCompatibilityLevelType(*compatibilityLevel))
+		plan.CompatibilityLevel = types.StringValue(compatibilityLevel)
 	}
 
-	// Convert *srclient.SchemaType to string
-	schemaTypeStr := utils.FromSchemaType(schema.SchemaType())
-
 	// Map response body to schema
 	plan.ID = types.StringValue(subject)
-	plan.Schema = jsontypes.NewNormalizedValue(schema.Schema())
-	plan.SchemaID = types.Int64Value(int64(schema.ID()))
-	plan.SchemaType = types.StringValue(schemaTypeStr)
-	plan.Version = types.Int64Value(int64(schema.Version()))
-	plan.Reference = utils.FromRegistryReferences(schema.References())
+	planned := plan
+	resp.Diagnostics.Append(r.setRegisteredSchema(ctx, schema, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	keepOmittedDataContract(&plan, planned)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -353,47 +713,55 @@ func (r *schemaResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	subject := state.Subject.ValueString()
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultSchemaTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	subject := r.qualifiedSubject(state)
 
 	// Fetch the latest schema from the registry
-	schema, err := r.client.GetLatestSchema(subject)
+	schema, err := r.client.GetSubjectVersion(ctx, subject, "latest", false)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Schema",
-			"Could not read schema: "+err.Error(),
-		)
+		if utils.IsNotFound(err) {
+			// The subject was deleted outside of Terraform, so plan a re-create.
+			// Soft-deleted subjects are reported as not found too
+			tflog.Warn(ctx, "Subject not found in Schema Registry, it was deleted or soft deleted outside of "+
+				"Terraform, removing from state", map[string]interface{}{
+				"subject": subject,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addRegistryError(&resp.Diagnostics, "Error Reading Schema", "Could not read schema", err)
 		return
 	}
 
 	// Fetch the current compatibility level from the server
-	compatibilityLevel, err := r.client.GetCompatibilityLevel(subject, true)
+	compatibilityLevel, err := r.client.GetCompatibilityLevel(ctx, subject)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error getting compatibility level",
-			"Could not get compatibility level, unexpected error: "+err.Error(),
-		)
+		addRegistryError(&resp.Diagnostics, "Error getting compatibility level",
+			"Could not get compatibility level", err)
 		return
 	}
 
-	schemaString := schema.Schema()
-	schemaID := int64(schema.ID())
-	schemaVersion := int64(schema.Version())
-	schemaType := utils.FromSchemaType(schema.SchemaType())
-	references := utils.FromRegistryReferences(schema.References())
-	compatString := utils.FromCompatibilityLevelType(*compatibilityLevel)
-
 	// Update state with refreshed values
-	state.Schema = jsontypes.NewNormalizedValue(schemaString)
-	state.SchemaID = types.Int64Value(schemaID)
-	state.SchemaType = types.StringValue(schemaType)
-	state.Version = types.Int64Value(schemaVersion)
-	state.Reference = references
-	state.CompatibilityLevel = types.StringValue(compatString)
+	state.CompatibilityLevel = types.StringValue(compatibilityLevel)
+	resp.Diagnostics.Append(r.setRegisteredSchema(ctx, schema, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Ensure hard delete is set to false if not specified
 	if state.HardDelete.IsNull() || state.HardDelete.IsUnknown() {
 		state.HardDelete = types.BoolValue(false)
 	}
+	if state.SchemaIDStability.IsNull() || state.SchemaIDStability.IsUnknown() {
+		state.SchemaIDStability = types.StringValue(compatibilityCheckDisabled)
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -421,41 +789,46 @@ func (r *schemaResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultSchemaTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Generate API request body from plan
-	subject := plan.Subject.ValueString()
-	references, diags := utils.ToRegistryReferences(ctx, plan.Reference)
+	subject := r.qualifiedSubject(plan)
+	schemaReq, diags := r.schemaRequest(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	clearRemovedDataContract(&schemaReq, plan, state)
 
 	// Update or fetch the schema
-	schema, err := r.updateSchema(ctx, subject, plan, state, references)
+	schema, err := r.updateSchema(ctx, subject, plan, state, schemaReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating schema",
-			err.Error(),
-		)
+		addRegistryError(&resp.Diagnostics, "Error updating schema", "Could not update schema", err)
 		return
 	}
 
 	// Update or fetch the compatibility level
-	compatibilityLevel, err := r.updateCompatibilityLevel(subject, plan)
+	compatibilityLevel, err := r.updateCompatibilityLevel(ctx, subject, plan)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating compatibility level",
-			err.Error(),
-		)
+		addRegistryError(&resp.Diagnostics, "Error updating compatibility level",
+			"Could not update compatibility level", err)
 		return
 	}
 
 	// Update state with refreshed values
-	plan.Schema = jsontypes.NewNormalizedValue(schema.Schema())
-	plan.SchemaType = types.StringValue(utils.FromSchemaType(schema.SchemaType()))
-	plan.SchemaID = types.Int64Value(int64(schema.ID()))
-	plan.Version = types.Int64Value(int64(schema.Version()))
-	plan.Reference = utils.FromRegistryReferences(schema.References())
 	plan.CompatibilityLevel = types.StringValue(compatibilityLevel)
+	planned := plan
+	resp.Diagnostics.Append(r.setRegisteredSchema(ctx, schema, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	keepOmittedDataContract(&plan, planned)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -465,29 +838,20 @@ func (r *schemaResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 // updateSchema updates the schema if it changed, or fetches the current schema if not.
-func (r *schemaResource) updateSchema(ctx context.Context, subject string, plan, state schemaResourceModel, references []srclient.Reference) (*srclient.Schema, error) {
-	schemaString := plan.Schema.ValueString()
-	schemaType := utils.ToSchemaType(plan.SchemaType.ValueString())
-
-	// Schema hasn't changed, just fetch the current schema
-	if plan.Schema.Equal(state.Schema) {
-		schema, err := r.client.GetLatestSchema(subject)
+func (r *schemaResource) updateSchema(ctx context.Context, subject string, plan, state schemaResourceModel,
+	schemaReq utils.SchemaRequest) (*utils.RegisteredSchema, error) {
+	// Neither the schema nor its data contract changed, just fetch the current schema
+	if plan.Schema.Equal(state.Schema) && plan.Metadata.Equal(state.Metadata) && plan.RuleSet.Equal(state.RuleSet) {
+		schema, err := r.client.GetSubjectVersion(ctx, subject, "latest", false)
 		if err != nil {
-			return nil, fmt.Errorf("could not fetch current schema: %w", err)
+			return nil, fmt.Errorf("could not fetch current schema: %w", utils.ClassifyError(err))
 		}
 		return schema, nil
 	}
 
-	// Schema string has changed, check semantic equivalence. This is a fallback
+	// Schema or data contract has changed, check semantic equivalence. This is a fallback
 	// in case ModifyPlan is skipped or fails
-	equal, err := utils.IsSemanticallyEqual(
-		ctx,
-		r.client,
-		subject,
-		schemaString,
-		schemaType,
-		references,
-	)
+	equal, err := utils.IsSemanticallyEqual(ctx, r.client, subject, schemaReq, r.lookupNormalizes(plan))
 	if err != nil {
 		// If semantic check fails, assume they differ since we know
 		// the strings already differ from above
@@ -496,38 +860,77 @@ func (r *schemaResource) updateSchema(ctx context.Context, subject string, plan,
 
 	// Schema has changed, update it
 	if !equal {
-		schema, err := r.client.CreateSchema(subject, schemaString, schemaType, references...)
+		schema, err := r.registerSchema(ctx, subject, plan, schemaReq)
 		if err != nil {
-			return nil, fmt.Errorf("could not update schema: %w", err)
+			return nil, fmt.Errorf("could not update schema: %w", utils.ClassifyError(err))
 		}
 		return schema, nil
 	}
 
 	// Schemas are semantically equivalent, just fetch the current schema
-	schema, err := r.client.GetLatestSchema(subject)
+	schema, err := r.client.GetSubjectVersion(ctx, subject, "latest", false)
 	if err != nil {
-		return nil, fmt.Errorf("could not fetch current schema: %w", err)
+		return nil, fmt.Errorf("could not fetch current schema: %w", utils.ClassifyError(err))
 	}
 	return schema, nil
 }
 
+// registerSchema registers the planned schema under the subject. When
+// schema_id or version are set, the schema is registered under them, which
+// the registry only allows in IMPORT mode.
+func (r *schemaResource) registerSchema(ctx context.Context, subject string, plan schemaResourceModel,
+	req utils.SchemaRequest) (*utils.RegisteredSchema, error) {
+	if !plan.SchemaID.IsUnknown() && !plan.SchemaID.IsNull() {
+		req.ID = int(plan.SchemaID.ValueInt64())
+	}
+	if !plan.Version.IsUnknown() && !plan.Version.IsNull() {
+		req.Version = int(plan.Version.ValueInt64())
+	}
+
+	if req.ID != 0 || req.Version != 0 {
+		mode, err := r.client.GetMode(ctx, subject, true)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the mode of subject %s: %w", subject, err)
+		}
+		if mode != utils.ModeImport {
+			return nil, fmt.Errorf("schema_id and version can only be set while the subject or registry is in "+
+				"%s mode, but subject %s is in %s mode", utils.ModeImport, subject, mode)
+		}
+
+		tflog.Debug(ctx, "Registering schema in IMPORT mode", map[string]interface{}{
+			"subject":   subject,
+			"schema_id": req.ID,
+			"version":   req.Version,
+		})
+	}
+	if _, err := r.client.RegisterSchema(ctx, subject, req, r.normalizes(plan)); err != nil {
+		return nil, err
+	}
+
+	version := "latest"
+	if req.Version != 0 {
+		version = strconv.Itoa(req.Version)
+	}
+	return r.client.WaitForSubjectVersion(ctx, subject, version)
+}
+
 // updateCompatibilityLevel updates or fetches the compatibility level.
-func (r *schemaResource) updateCompatibilityLevel(subject string, plan schemaResourceModel) (string, error) {
+func (r *schemaResource) updateCompatibilityLevel(ctx context.Context, subject string,
+	plan schemaResourceModel) (string, error) {
 	if !plan.CompatibilityLevel.IsNull() && !plan.CompatibilityLevel.IsUnknown() {
-		compatibilityLevel := utils.ToCompatibilityLevelType(plan.CompatibilityLevel.ValueString())
-		_, err := r.client.ChangeSubjectCompatibilityLevel(subject, compatibilityLevel)
+		err := r.client.UpdateConfig(ctx, subject, &utils.Config{CompatibilityLevel: plan.CompatibilityLevel.ValueString()})
 		if err != nil {
-			return "", fmt.Errorf("could not set compatibility level: %w", err)
+			return "", fmt.Errorf("could not set compatibility level: %w", utils.ClassifyError(err))
 		}
 		return plan.CompatibilityLevel.ValueString(), nil
 	}
 
 	// Fetch the global compatibility level from the server
-	cl, err := r.client.GetCompatibilityLevel(subject, true)
+	compatibilityLevel, err := r.client.GetCompatibilityLevel(ctx, subject)
 	if err != nil {
-		return "", fmt.Errorf("could not get compatibility level: %w", err)
+		return "", fmt.Errorf("could not get compatibility level: %w", utils.ClassifyError(err))
 	}
-	return utils.FromCompatibilityLevelType(*cl), nil
+	return compatibilityLevel, nil
 }
 
 func (r *schemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -549,68 +952,125 @@ func (r *schemaResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultSchemaTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// The registry may have gained references since the plan was made
+	r.checkReferencesBeforeDelete(ctx, state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	hardDelete := false
 	if !state.HardDelete.IsNull() && !state.HardDelete.IsUnknown() {
 		hardDelete = state.HardDelete.ValueBool()
 	}
 
 	// Delete existing schema
-	err := r.client.DeleteSubject(state.Subject.ValueString(), hardDelete)
+	subject := r.qualifiedSubject(state)
+	err := r.client.DeleteSubject(ctx, subject, hardDelete)
+	if err != nil && utils.IsNotFound(err) {
+		// Nothing left to delete, e.g. the subject was removed outside of Terraform
+		tflog.Warn(ctx, "Subject not found in Schema Registry during delete", map[string]interface{}{
+			"subject": subject,
+		})
+		err = nil
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Schema",
-			"Could not delete schema, unexpected error: "+err.Error(),
-		)
+		addRegistryError(&resp.Diagnostics, "Error Deleting Schema", "Could not delete schema", err)
 		return
 	}
 
 	resp.State.RemoveResource(ctx)
 
-	tflog.Info(ctx, fmt.Sprintf("Schema %s deleted (%s delete)", state.Subject.ValueString(),
+	tflog.Info(ctx, fmt.Sprintf("Schema %s deleted (%s delete)", subject,
 		map[bool]string{true: "hard", false: "soft"}[hardDelete]))
 }
 
+// checkReferencesBeforeDelete adds an error listing the subjects that still
+// reference any version of the subject when prevent_delete_if_referenced is
+// set.
+func (r *schemaResource) checkReferencesBeforeDelete(ctx context.Context, state schemaResourceModel,
+	diags *diag.Diagnostics) {
+	if !state.PreventDeleteIfReferenced.ValueBool() {
+		return
+	}
+
+	subject := r.qualifiedSubject(state)
+	versions, err := r.client.ListSubjectVersions(ctx, subject, false)
+	if utils.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		addRegistryError(diags, "Error Checking Schema References",
+			fmt.Sprintf("Could not list versions of subject %s", subject), err)
+		return
+	}
+
+	var referencing []string
+	for _, version := range versions {
+		_, refs, err := r.client.GetReferencingSubjectVersions(ctx, subject, strconv.Itoa(version))
+		if err != nil {
+			addRegistryError(diags, "Error Checking Schema References",
+				fmt.Sprintf("Could not read references to version %d of subject %s", version, subject), err)
+			return
+		}
+		for _, ref := range refs {
+			referencing = append(referencing, fmt.Sprintf("%s version %d (references version %d)",
+				ref.Subject, ref.Version, version))
+		}
+	}
+	if len(referencing) == 0 {
+		return
+	}
+
+	diags.AddError("Schema Is Still Referenced",
+		fmt.Sprintf("Subject %s cannot be deleted because prevent_delete_if_referenced is set and other "+
+			"schemas still reference it:\n- %s\n\nRemove or update the referencing schemas first.",
+			subject, strings.Join(referencing, "\n- ")))
+}
+
 func (r *schemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse) {
-	subject := req.ID
+	// Unqualified subjects are imported from the provider's default context
+	subject := utils.QualifySubject(r.schemaContext, req.ID)
 
 	// Retrieve the latest schema for the subject
-	schema, err := r.client.GetLatestSchema(subject)
+	schema, err := r.client.GetSubjectVersion(ctx, subject, "latest", false)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Importing Schema",
-			fmt.Sprintf("Could not retrieve schema for subject %s: %s", subject,
-				err.Error(),
-			),
-		)
+		addRegistryError(&resp.Diagnostics, "Error Importing Schema",
+			fmt.Sprintf("Could not retrieve schema for subject %s", subject), err)
 		return
 	}
 
 	// Retrieve the compatibility level for the subject
-	compatibilityLevel, err := r.client.GetCompatibilityLevel(subject, true)
+	compatibilityLevel, err := r.client.GetCompatibilityLevel(ctx, subject)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Importing Schema",
-			fmt.Sprintf("Could not retrieve compatibility level for subject %s: %s", subject,
-				err.Error(),
-			),
-		)
+		addRegistryError(&resp.Diagnostics, "Error Importing Schema",
+			fmt.Sprintf("Could not retrieve compatibility level for subject %s", subject), err)
 		return
 	}
 
-	schemaType := utils.FromSchemaType(schema.SchemaType())
-
 	// Create state from retrieved schema
+	subjectName, schemaContext := splitImportID(req.ID, r.schemaContext)
 	state := schemaResourceModel{
-		ID:                 types.StringValue(subject),
-		Subject:            types.StringValue(subject),
-		Schema:             jsontypes.NewNormalizedValue(schema.Schema()),
-		SchemaID:           types.Int64Value(int64(schema.ID())),
-		SchemaType:         types.StringValue(schemaType),
-		Version:            types.Int64Value(int64(schema.Version())),
-		Reference:          utils.FromRegistryReferences(schema.References()),
-		CompatibilityLevel: types.StringValue(utils.FromCompatibilityLevelType(*compatibilityLevel)),
-		HardDelete:         types.BoolValue(false), // Default to false for imported resources
+		ID:                        types.StringValue(subject),
+		Subject:                   subjectName,
+		Context:                   schemaContext,
+		CompatibilityLevel:        types.StringValue(compatibilityLevel),
+		HardDelete:                types.BoolValue(false), // Default to false for imported resources
+		PreventDeleteIfReferenced: types.BoolValue(false),
+		SchemaIDStability:         types.StringValue(compatibilityCheckDisabled),
+		Timeouts:                  timeouts.Value{Object: types.ObjectNull(timeoutsAttrTypes)},
+	}
+	resp.Diagnostics.Append(r.setRegisteredSchema(ctx, schema, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Set the state
@@ -620,3 +1080,130 @@ func (r *schemaResource) ImportState(ctx context.Context, req resource.ImportSta
 		return
 	}
 }
+
+// qualifiedSubject returns the subject of m qualified with its schema
+// context, as used in registry requests.
+func (r *schemaResource) qualifiedSubject(m schemaResourceModel) string {
+	return qualifySubject(m.Context, r.schemaContext, m.Subject.ValueString())
+}
+
+// normalizes reports whether the schema of m is normalized when it is
+// registered and checked for compatibility.
+func (r *schemaResource) normalizes(m schemaResourceModel) bool {
+	return r.normalizeSetting(m, false)
+}
+
+// lookupNormalizes reports whether the schema of m is normalized when it is
+// looked up to detect changes. Unless normalize is set, lookups normalize so
+// that changes which only reformat the schema plan nothing.
+func (r *schemaResource) lookupNormalizes(m schemaResourceModel) bool {
+	return r.normalizeSetting(m, true)
+}
+
+// normalizeSetting returns the normalize setting of m, falling back to the
+// provider setting and then to fallback.
+func (r *schemaResource) normalizeSetting(m schemaResourceModel, fallback bool) bool {
+	switch {
+	case !m.Normalize.IsNull() && !m.Normalize.IsUnknown():
+		return m.Normalize.ValueBool()
+	case r.normalize != nil:
+		return *r.normalize
+	}
+	return fallback
+}
+
+// schemaRequest builds the registry request that registers or looks up the
+// schema of m, including its references and data contract.
+func (r *schemaResource) schemaRequest(ctx context.Context, m schemaResourceModel) (utils.SchemaRequest,
+	diag.Diagnostics) {
+	references, diags := r.registryReferences(ctx, m)
+	req := utils.NewSchemaRequest(m.Schema.ValueString(), utils.ToSchemaType(m.SchemaType.ValueString()), references)
+
+	var d diag.Diagnostics
+	req.Metadata, d = utils.ToRegistryMetadata(ctx, m.Metadata)
+	diags.Append(d...)
+	req.RuleSet, d = utils.ToRegistryRuleSet(ctx, m.RuleSet)
+	diags.Append(d...)
+	return req, diags
+}
+
+// setRegisteredSchema sets the schema, its identifiers, references and data
+// contract in m to those of a registered version of the subject.
+func (r *schemaResource) setRegisteredSchema(ctx context.Context, registered *utils.RegisteredSchema,
+	m *schemaResourceModel) diag.Diagnostics {
+	// Keep the configured or prior form of the schema, which may differ from
+	// the registry's normalized or reformatted form, unless the registry now
+	// holds a different schema than the one last registered
+	switch {
+	case m.Schema.IsNull() || m.Schema.IsUnknown():
+		m.Schema = types.StringValue(registered.Schema)
+	case m.RegisteredSchema.IsUnknown():
+		// Applying a plan keeps the planned schema
+	case m.RegisteredSchema.IsNull():
+		if !utils.SchemasEqual(registered.Type(), m.Schema.ValueString(), registered.Schema) {
+			m.Schema = types.StringValue(registered.Schema)
+		}
+	case !utils.SchemasEqual(registered.Type(), m.RegisteredSchema.ValueString(), registered.Schema):
+		m.Schema = types.StringValue(registered.Schema)
+	}
+	m.RegisteredSchema = types.StringValue(registered.Schema)
+	m.SchemaID = types.Int64Value(int64(registered.ID))
+	m.SchemaType = types.StringValue(registered.Type())
+	m.Version = types.Int64Value(int64(registered.Version))
+	m.Reference = r.referencesValue(*m, registered.References)
+
+	// An empty data contract is no data contract unless one was configured
+	var diags, d diag.Diagnostics
+	metadata, ruleSet := utils.WithoutGeneratedProperties(registered.Metadata), registered.RuleSet
+	if m.Metadata.IsNull() && utils.MetadataEqual(metadata, nil) {
+		metadata = nil
+	}
+	if m.RuleSet.IsNull() && utils.RuleSetEqual(ruleSet, nil) {
+		ruleSet = nil
+	}
+	m.Metadata, d = utils.FromRegistryMetadata(ctx, metadata)
+	diags.Append(d...)
+	m.RuleSet, d = utils.FromRegistryRuleSet(ctx, ruleSet)
+	diags.Append(d...)
+	return diags
+}
+
+// clearRemovedDataContract makes req register the schema with an empty
+// metadata or rule set when they were removed from the configuration, since
+// the registry carries over those of the previous version when they are
+// omitted.
+func clearRemovedDataContract(req *utils.SchemaRequest, plan, state schemaResourceModel) {
+	if plan.Metadata.IsNull() && !state.Metadata.IsNull() {
+		req.Metadata = &utils.Metadata{}
+	}
+	if plan.RuleSet.IsNull() && !state.RuleSet.IsNull() {
+		req.RuleSet = &utils.RuleSet{}
+	}
+}
+
+// keepOmittedDataContract keeps the metadata and rule set of m null when they
+// are null in planned, since the registry may apply those of the subject
+// defaults, which are not planned. A refresh reports them as drift.
+func keepOmittedDataContract(m *schemaResourceModel, planned schemaResourceModel) {
+	if planned.Metadata.IsNull() {
+		m.Metadata = planned.Metadata
+	}
+	if planned.RuleSet.IsNull() {
+		m.RuleSet = planned.RuleSet
+	}
+}
+
+// registryReferences converts the references of m for a registry request,
+// qualifying their subjects with the schema context of m.
+func (r *schemaResource) registryReferences(ctx context.Context, m schemaResourceModel) ([]srclient.Reference,
+	diag.Diagnostics) {
+	references, diags := utils.ToRegistryReferences(ctx, m.Reference)
+	return utils.QualifyReferences(effectiveContext(m.Context, r.schemaContext), references), diags
+}
+
+// referencesValue converts references returned by the registry into a list
+// value, stripping the schema context of m from their subjects.
+func (r *schemaResource) referencesValue(m schemaResourceModel, references []srclient.Reference) types.List {
+	return utils.FromRegistryReferences(utils.UnqualifyReferences(effectiveContext(m.Context, r.schemaContext),
+		references))
+}
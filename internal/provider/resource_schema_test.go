@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
@@ -59,7 +60,7 @@ func TestAccSchemaResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "schema_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "version"),
 					resource.TestCheckResourceAttrWith(resourceName, "schema", func(state string) error {
-						return ValidateSchemaString(initialSchema, state)
+						return ValidateSchemaString("AVRO", initialSchema, state)
 					}),
 				),
 			},
@@ -81,7 +82,7 @@ func TestAccSchemaResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "schema_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "version"),
 					resource.TestCheckResourceAttrWith(resourceName, "schema", func(state string) error {
-						return ValidateSchemaString(updatedSchema, state)
+						return ValidateSchemaString("AVRO", updatedSchema, state)
 					}),
 				),
 			},
@@ -97,7 +98,7 @@ func TestAccSchemaResource_basic(t *testing.T) {
 					if state.Attributes["subject"] != subjectName {
 						return fmt.Errorf("expected subject %s, got %s", subjectName, state.Attributes["subject"])
 					}
-					err := ValidateSchemaString(expectedSchema, state.Attributes["schema"])
+					err := ValidateSchemaString("AVRO", expectedSchema, state.Attributes["schema"])
 					if err != nil {
 						return fmt.Errorf("schema validation error: %v", err)
 					}
@@ -222,7 +223,7 @@ func TestAccSchemaResource_modifyPlan(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "schema_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "version"),
 					resource.TestCheckResourceAttrWith(resourceName, "schema", func(state string) error {
-						return ValidateSchemaString(formattedSchema, state)
+						return ValidateSchemaString("AVRO", formattedSchema, state)
 					}),
 				),
 			},
@@ -241,7 +242,7 @@ func TestAccSchemaResource_modifyPlan(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "schema_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "version"),
 					resource.TestCheckResourceAttrWith(resourceName, "schema", func(state string) error {
-						return ValidateSchemaString(differentSchema, state)
+						return ValidateSchemaString("AVRO", differentSchema, state)
 					}),
 				),
 			},
@@ -281,7 +282,7 @@ func TestAccSchemaResource_compatibilityLevelChange(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "schema_type", "AVRO"),
 					resource.TestCheckResourceAttr(resourceName, "compatibility_level", "BACKWARD"),
 					resource.TestCheckResourceAttrWith(resourceName, "schema", func(state string) error {
-						return ValidateSchemaString(schema, state)
+						return ValidateSchemaString("AVRO", schema, state)
 					}),
 				),
 			},
@@ -294,7 +295,7 @@ func TestAccSchemaResource_compatibilityLevelChange(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "schema_type", "AVRO"),
 					resource.TestCheckResourceAttr(resourceName, "compatibility_level", "FULL"),
 					resource.TestCheckResourceAttrWith(resourceName, "schema", func(state string) error {
-						return ValidateSchemaString(schema, state)
+						return ValidateSchemaString("AVRO", schema, state)
 					}),
 				),
 			},
@@ -306,7 +307,7 @@ func TestAccSchemaResource_compatibilityLevelChange(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "schema_type", "AVRO"),
 					resource.TestCheckResourceAttr(resourceName, "compatibility_level", "FORWARD_TRANSITIVE"),
 					resource.TestCheckResourceAttrWith(resourceName, "schema", func(state string) error {
-						return ValidateSchemaString(schema, state)
+						return ValidateSchemaString("AVRO", schema, state)
 					}),
 				),
 			},
@@ -314,6 +315,290 @@ func TestAccSchemaResource_compatibilityLevelChange(t *testing.T) {
 	})
 }
 
+func TestAccSchemaResource_deletedOutOfBand(t *testing.T) {
+	subjectName := acctest.RandomWithPrefix("tf-acc-test-deleted")
+	resourceName := "schemaregistry_schema.test_01"
+
+	deleteSubject := func(permanent bool) func() {
+		return func() {
+			if err := testAccClient().DeleteSubject(subjectName, permanent); err != nil {
+				t.Fatalf("failed to delete subject %s: %s", subjectName, err)
+			}
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaResourceConfig_basic(subjectName),
+				Check:  resource.TestCheckResourceAttrSet(resourceName, "schema_id"),
+			},
+			// A soft-deleted subject is removed from state and planned for re-creation
+			{
+				PreConfig:          deleteSubject(false),
+				Config:             testAccSchemaResourceConfig_basic(subjectName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccSchemaResourceConfig_basic(subjectName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "subject", subjectName),
+					resource.TestCheckResourceAttrSet(resourceName, "schema_id"),
+				),
+			},
+			// A hard-deleted subject is removed from state and planned for re-creation
+			{
+				PreConfig:          deleteSubject(true),
+				Config:             testAccSchemaResourceConfig_basic(subjectName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccSchemaResourceConfig_basic(subjectName),
+				Check:  resource.TestCheckResourceAttr(resourceName, "subject", subjectName),
+			},
+		},
+	})
+}
+
+func TestAccSchemaResource_errors(t *testing.T) {
+	subjectName := acctest.RandomWithPrefix("tf-acc-test-errors")
+
+	// Adding a field without a default breaks BACKWARD compatibility
+	incompatibleSchema := `{
+    "type": "record",
+    "name": "Test",
+    "fields": [
+        {
+            "name": "f1",
+            "type": "string"
+        },
+        {
+            "name": "f2",
+            "type": "int"
+        }
+    ]
+}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaResourceConfig_withCompatibility(subjectName, initialSchema, "BACKWARD"),
+			},
+			// Incompatible changes are rejected during plan
+			{
+				Config:      testAccSchemaResourceConfig_withCompatibility(subjectName, incompatibleSchema, "BACKWARD"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Incompatible schema change`),
+			},
+			// With warnings only, the registry rejects the change during apply
+			{
+				Config:      testAccSchemaResourceConfig_withCompatibilityCheck(subjectName, incompatibleSchema, "warn"),
+				ExpectError: regexp.MustCompile(`not compatible with earlier versions`),
+			},
+			{
+				ResourceName:  "schemaregistry_schema.test_01",
+				ImportState:   true,
+				ImportStateId: subjectName + "-missing",
+				ExpectError:   regexp.MustCompile(`subject does not exist in the Schema Registry`),
+			},
+		},
+	})
+}
+
+func TestAccSchemaResource_protobuf(t *testing.T) {
+	subjectName := acctest.RandomWithPrefix("tf-acc-test-protobuf")
+	resourceName := "schemaregistry_schema.test_01"
+
+	protobufSchema := `syntax = "proto3";
+package com.example;
+
+// An order placed by a customer.
+message Order {
+  string id = 1;
+  int64 created_at = 2;
+}
+`
+
+	// Same definition without comments, reordered and reformatted
+	reformattedSchema := `syntax = "proto3"; package com.example;
+message Order { int64 created_at = 2; string id = 1; }`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaResourceConfig_protobuf(subjectName, protobufSchema),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "subject", subjectName),
+					resource.TestCheckResourceAttr(resourceName, "schema_type", "PROTOBUF"),
+					resource.TestCheckResourceAttrSet(resourceName, "schema_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "version"),
+					resource.TestCheckResourceAttrWith(resourceName, "schema", func(state string) error {
+						return ValidateSchemaString("PROTOBUF", protobufSchema, state)
+					}),
+				),
+			},
+			// Formatting, comment and ordering changes must not produce a diff
+			{
+				Config:   testAccSchemaResourceConfig_protobuf(subjectName, reformattedSchema),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccSchemaResource_explicitIDTaken(t *testing.T) {
+	subjectName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaResourceConfig_basic(subjectName),
+			},
+			// Reusing the ID of a different schema must fail during plan
+			{
+				Config:      testAccSchemaResourceConfig_explicitID(subjectName, subjectName+"-import", updatedSchema),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Schema ID already in use`),
+			},
+		},
+	})
+}
+
+func TestAccSchemaResource_preventDeleteIfReferenced(t *testing.T) {
+	subjectName := acctest.RandomWithPrefix("tf-acc-test")
+	refName := acctest.RandomWithPrefix("tf-acc-test-ref")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaResourceConfig_referenced(subjectName, refName, true, true),
+			},
+			// Destroying the referenced schema must fail during plan
+			{
+				Config:      testAccSchemaResourceConfig_referenced(subjectName, refName, true, false),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Schema Is Still Referenced`),
+			},
+			// Lift the safeguard so the test can clean up
+			{
+				Config: testAccSchemaResourceConfig_referenced(subjectName, refName, false, true),
+			},
+		},
+	})
+}
+
+func TestAccSchemaResource_context(t *testing.T) {
+	testAccPreCheckFeature(t, "contexts")
+	subjectName := acctest.RandomWithPrefix("tf-acc-test")
+	refName := acctest.RandomWithPrefix("tf-acc-test-ref")
+	schemaContext := "." + acctest.RandomWithPrefix("tf-acc-test-ctx")
+	resourceName := "schemaregistry_schema.test_01"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaResourceConfig_context(subjectName, refName, schemaContext),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", ":"+schemaContext+":"+subjectName),
+					resource.TestCheckResourceAttr(resourceName, "subject", subjectName),
+					resource.TestCheckResourceAttr(resourceName, "context", schemaContext),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+					resource.TestCheckResourceAttr(resourceName, "references.0.subject", refName),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           ":" + schemaContext + ":" + subjectName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"subject", "context", "references", "hard_delete"},
+			},
+		},
+	})
+}
+
+func TestAccSchemaResource_schemaIDStability(t *testing.T) {
+	subjectName := acctest.RandomWithPrefix("tf-acc-test")
+	renamedSubject := subjectName + "-renamed"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaResourceConfig_schemaIDStability(subjectName, initialSchema),
+			},
+			// Renaming the subject keeps the schema, so the ID is reused
+			{
+				Config:             testAccSchemaResourceConfig_schemaIDStability(renamedSubject, initialSchema),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Renaming the subject and changing the schema assigns a new ID
+			{
+				Config:      testAccSchemaResourceConfig_schemaIDStability(renamedSubject, updatedSchema),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Schema ID would change`),
+			},
+		},
+	})
+}
+
+func TestAccSchemaResource_normalize(t *testing.T) {
+	subjectName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "schemaregistry_schema.test_01"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaResourceConfig_normalize(subjectName, initialSchema),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "normalize", "true"),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+					resource.TestCheckResourceAttr(resourceName, "schema", initialSchema+"\n"),
+					resource.TestCheckResourceAttrSet(resourceName, "registered_schema"),
+				),
+			},
+			// The normalized lookup matches a compact copy of the schema
+			{
+				Config:   testAccSchemaResourceConfig_normalize(subjectName, NormalizeSchemaString(initialSchema)),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccSchemaResource_timeouts(t *testing.T) {
+	subjectName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "schemaregistry_schema.test_01"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaResourceConfig_timeouts(subjectName, initialSchema, "2m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "timeouts.create", "2m"),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+				),
+			},
+			// An operation that cannot finish in time fails instead of hanging
+			{
+				Config:      testAccSchemaResourceConfig_timeouts(subjectName, updatedSchema, "1ns"),
+				ExpectError: regexp.MustCompile(`context deadline exceeded`),
+			},
+		},
+	})
+}
+
 func testAccSchemaResourceConfig_base() string {
 	const baseTemplate = `
 provider "schemaregistry" {
@@ -566,3 +851,202 @@ EOF
 	return ConfigCompose(testAccSchemaResourceConfig_base(),
 		fmt.Sprintf(template, subject, compatibilityLevel, schema))
 }
+
+// testAccSchemaResourceConfig_protobuf creates a PROTOBUF schema configuration.
+func testAccSchemaResourceConfig_protobuf(subject, schema string) string {
+	const template = `
+resource "schemaregistry_schema" "test_01" {
+  subject              = "%s"
+  schema_type          = "PROTOBUF"
+  compatibility_level  = "NONE"
+  schema               = <<EOF
+%s
+EOF
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_base(),
+		fmt.Sprintf(template, subject, schema))
+}
+
+// testAccSchemaResourceConfig_withCompatibilityCheck creates a BACKWARD compatible
+// schema configuration with a specific plan-time compatibility check mode.
+func testAccSchemaResourceConfig_withCompatibilityCheck(subject, schema, compatibilityCheck string) string {
+	const template = `
+resource "schemaregistry_schema" "test_01" {
+  subject              = "%s"
+  schema_type          = "AVRO"
+  compatibility_level  = "BACKWARD"
+  compatibility_check  = "%s"
+  schema               = <<EOF
+%s
+EOF
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_base(),
+		fmt.Sprintf(template, subject, compatibilityCheck, schema))
+}
+
+func testAccSchemaResourceConfig_explicitID(subject, importSubject, schema string) string {
+	const explicitIDTemplate = `
+resource "schemaregistry_schema" "import_01" {
+  subject     = "%s"
+  schema_type = "AVRO"
+  schema_id   = schemaregistry_schema.test_01.schema_id
+  schema      = <<EOF
+%s
+EOF
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_basic(subject),
+		fmt.Sprintf(explicitIDTemplate, importSubject, schema))
+}
+
+// testAccSchemaResourceConfig_referenced creates a schema referencing ref_01
+// by literal subject name, so that ref_01 can be removed from the
+// configuration on its own.
+func testAccSchemaResourceConfig_referenced(subject, ref01 string, preventDelete, withRef bool) string {
+	const refTemplate = `
+resource "schemaregistry_schema" "ref_01" {
+  subject                      = "%s"
+  schema_type                  = "AVRO"
+  prevent_delete_if_referenced = %t
+  schema                       = <<EOF
+{
+  "type": "record",
+  "name": "TestRef01",
+  "fields": [
+    {
+      "name": "f1",
+      "type": "string"
+    }
+  ]
+}
+EOF
+}
+`
+	const referencingTemplate = `
+resource "schemaregistry_schema" "test_01" {
+  subject     = "%s"
+  schema_type = "AVRO"
+  schema = jsonencode({
+    "type" : "record",
+    "name" : "Test",
+    "fields" : [
+      {
+        "name" : "ref",
+        "type" : "TestRef01"
+      }
+    ]
+  })
+  references = [
+    {
+      name    = "TestRef01"
+      subject = "%s"
+      version = 1
+    },
+  ]
+  %s
+}
+`
+	if !withRef {
+		return ConfigCompose(testAccSchemaResourceConfig_base(),
+			fmt.Sprintf(referencingTemplate, subject, ref01, ""))
+	}
+	return ConfigCompose(testAccSchemaResourceConfig_base(),
+		fmt.Sprintf(refTemplate, ref01, preventDelete),
+		fmt.Sprintf(referencingTemplate, subject, ref01, "depends_on = [schemaregistry_schema.ref_01]"))
+}
+
+func testAccSchemaResourceConfig_context(subject, ref01, schemaContext string) string {
+	const template = `
+resource "schemaregistry_schema" "ref_01" {
+  subject     = "%[2]s"
+  context     = "%[3]s"
+  schema_type = "AVRO"
+  schema = jsonencode({
+    "type" : "record",
+    "name" : "TestRef01",
+    "fields" : [{ "name" : "f1", "type" : "string" }]
+  })
+}
+
+resource "schemaregistry_schema" "test_01" {
+  subject     = "%[1]s"
+  context     = "%[3]s"
+  schema_type = "AVRO"
+  schema = jsonencode({
+    "type" : "record",
+    "name" : "Test",
+    "fields" : [{ "name" : "ref", "type" : "TestRef01" }]
+  })
+  references = [
+    {
+      name    = "TestRef01"
+      subject = "%[2]s"
+      version = 1
+    },
+  ]
+
+  depends_on = [schemaregistry_schema.ref_01]
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_base(), fmt.Sprintf(template, subject, ref01, schemaContext))
+}
+
+// testAccSchemaResourceConfig_schemaIDStability creates a schema whose
+// replacement must keep its schema ID.
+func testAccSchemaResourceConfig_schemaIDStability(subject, schema string) string {
+	const template = `
+resource "schemaregistry_schema" "test_01" {
+  subject             = "%s"
+  schema_type         = "AVRO"
+  compatibility_level = "NONE"
+  schema_id_stability = "error"
+  schema              = <<EOF
+%s
+EOF
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_base(),
+		fmt.Sprintf(template, subject, schema))
+}
+
+// testAccSchemaResourceConfig_normalize creates a schema that is normalized
+// when it is registered and looked up.
+func testAccSchemaResourceConfig_normalize(subject, schema string) string {
+	const template = `
+resource "schemaregistry_schema" "test_01" {
+  subject             = "%s"
+  schema_type         = "AVRO"
+  compatibility_level = "NONE"
+  normalize           = true
+  schema              = <<EOF
+%s
+EOF
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_base(),
+		fmt.Sprintf(template, subject, schema))
+}
+
+// testAccSchemaResourceConfig_timeouts creates a schema whose operations time
+// out after timeout.
+func testAccSchemaResourceConfig_timeouts(subject, schema, timeout string) string {
+	const template = `
+resource "schemaregistry_schema" "test_01" {
+  subject             = "%s"
+  schema_type         = "AVRO"
+  compatibility_level = "NONE"
+  schema              = <<EOF
+%s
+EOF
+
+  timeouts {
+    create = "2m"
+    update = "%s"
+  }
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_base(),
+		fmt.Sprintf(template, subject, schema, timeout))
+}
@@ -0,0 +1,647 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/riferrei/srclient"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/fakeregistry"
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+)
+
+const (
+	testUnitSchemaV1 = `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`
+	testUnitSchemaV2 = `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"},` +
+		`{"name":"total","type":"double","default":0}]}`
+	testUnitSchemaIncompatible = `{"type":"record","name":"Order","fields":[{"name":"id","type":"long"}]}`
+)
+
+// testUnitSchemaResource returns a schema resource configured against an
+// in-memory registry, and a client for out-of-band changes to it.
+func testUnitSchemaResource(t *testing.T) (*fakeregistry.Registry, *utils.Client, *schemaResource, schema.Schema) {
+	t.Helper()
+	ctx := context.Background()
+
+	reg, client := testUnitClient(t)
+
+	r := &schemaResource{}
+	var configureResp resource.ConfigureResponse
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: &providerData{
+		client:             client,
+		compatibilityCheck: compatibilityCheckError,
+	}}, &configureResp)
+	testUnitNoErrors(t, "Configure", configureResp.Diagnostics)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	testUnitNoErrors(t, "Schema", schemaResp.Diagnostics)
+
+	return reg, client, r, schemaResp.Schema
+}
+
+// testUnitSchemaModel returns a planned model of an Avro schema, with the
+// computed attributes unknown as Terraform would plan them.
+func testUnitSchemaModel(subject, schemaString string) schemaResourceModel {
+	return schemaResourceModel{
+		ID:                        types.StringUnknown(),
+		Subject:                   types.StringValue(subject),
+		Context:                   types.StringNull(),
+		Schema:                    types.StringValue(schemaString),
+		RegisteredSchema:          types.StringUnknown(),
+		SchemaID:                  types.Int64Unknown(),
+		SchemaType:                types.StringValue("AVRO"),
+		Version:                   types.Int64Unknown(),
+		Reference:                 types.ListNull(types.ObjectType{AttrTypes: utils.ReferenceAttrTypes}),
+		Metadata:                  types.ObjectNull(utils.MetadataAttrTypes),
+		RuleSet:                   types.ObjectNull(utils.RuleSetAttrTypes),
+		CompatibilityLevel:        types.StringUnknown(),
+		CompatibilityCheck:        types.StringNull(),
+		HardDelete:                types.BoolValue(false),
+		PreventDeleteIfReferenced: types.BoolValue(false),
+		SchemaIDStability:         types.StringValue(compatibilityCheckDisabled),
+		Normalize:                 types.BoolNull(),
+		Timeouts:                  timeouts.Value{Object: types.ObjectNull(timeoutsAttrTypes)},
+	}
+}
+
+func testUnitNoErrors(t *testing.T, operation string, diags diag.Diagnostics) {
+	t.Helper()
+
+	if diags.HasError() {
+		t.Fatalf("%s returned errors: %v", operation, diags.Errors())
+	}
+}
+
+func testUnitPlan(t *testing.T, s schema.Schema, m any) tfsdk.Plan {
+	t.Helper()
+
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+	testUnitNoErrors(t, "setting the plan", plan.Set(context.Background(), m))
+	return plan
+}
+
+func testUnitState(t *testing.T, s schema.Schema, m any) tfsdk.State {
+	t.Helper()
+
+	state := testUnitEmptyState(s)
+	testUnitNoErrors(t, "setting the state", state.Set(context.Background(), m))
+	return state
+}
+
+func testUnitEmptyState(s schema.Schema) tfsdk.State {
+	return tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+}
+
+func testUnitCreate(r *schemaResource, s schema.Schema, plan tfsdk.Plan) resource.CreateResponse {
+	resp := resource.CreateResponse{State: testUnitEmptyState(s)}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, &resp)
+	return resp
+}
+
+func testUnitRead(r *schemaResource, state tfsdk.State) resource.ReadResponse {
+	resp := resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+	return resp
+}
+
+func testUnitUpdate(r *schemaResource, s schema.Schema, plan tfsdk.Plan, state tfsdk.State) resource.UpdateResponse {
+	resp := resource.UpdateResponse{State: testUnitEmptyState(s)}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: plan, State: state}, &resp)
+	return resp
+}
+
+func testUnitDelete(r *schemaResource, state tfsdk.State) resource.DeleteResponse {
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+	return resp
+}
+
+func testUnitModel(t *testing.T, state tfsdk.State) schemaResourceModel {
+	t.Helper()
+
+	var m schemaResourceModel
+	testUnitNoErrors(t, "reading the state", state.Get(context.Background(), &m))
+	return m
+}
+
+func TestSchemaResource_unitCRUD(t *testing.T) {
+	ctx := context.Background()
+	_, client, r, s := testUnitSchemaResource(t)
+
+	createResp := testUnitCreate(r, s, testUnitPlan(t, s, testUnitSchemaModel("orders-value", testUnitSchemaV1)))
+	testUnitNoErrors(t, "Create", createResp.Diagnostics)
+	created := testUnitModel(t, createResp.State)
+	if created.ID.ValueString() != "orders-value" || created.Version.ValueInt64() != 1 ||
+		created.CompatibilityLevel.ValueString() != "BACKWARD" {
+		t.Errorf("Create state = ID %s version %d compatibility %s, want orders-value, 1 and BACKWARD",
+			created.ID, created.Version, created.CompatibilityLevel)
+	}
+
+	readResp := testUnitRead(r, createResp.State)
+	testUnitNoErrors(t, "Read", readResp.Diagnostics)
+	if read := testUnitModel(t, readResp.State); read.SchemaID.ValueInt64() != created.SchemaID.ValueInt64() {
+		t.Errorf("Read schema_id = %d, want %d", read.SchemaID.ValueInt64(), created.SchemaID.ValueInt64())
+	}
+
+	planned := testUnitSchemaModel("orders-value", testUnitSchemaV2)
+	planned.ID = created.ID
+	planned.CompatibilityLevel = types.StringValue("FULL")
+	updateResp := testUnitUpdate(r, s, testUnitPlan(t, s, planned), readResp.State)
+	testUnitNoErrors(t, "Update", updateResp.Diagnostics)
+	updated := testUnitModel(t, updateResp.State)
+	if updated.Version.ValueInt64() != 2 || updated.SchemaID.ValueInt64() == created.SchemaID.ValueInt64() {
+		t.Errorf("Update state = version %d schema_id %d, want version 2 and a new schema_id",
+			updated.Version.ValueInt64(), updated.SchemaID.ValueInt64())
+	}
+	config, err := client.GetConfig(ctx, "orders-value", false)
+	if err != nil || config.CompatibilityLevel != "FULL" {
+		t.Errorf("subject config = %+v, %v, want FULL", config, err)
+	}
+
+	deleteResp := testUnitDelete(r, updateResp.State)
+	testUnitNoErrors(t, "Delete", deleteResp.Diagnostics)
+	if !deleteResp.State.Raw.IsNull() {
+		t.Error("Delete did not remove the resource from state")
+	}
+	if softDeleted, err := testSubjectSoftDeleted(ctx, client, "orders-value"); err != nil || !softDeleted {
+		t.Errorf("testSubjectSoftDeleted() = %t, %v, want true", softDeleted, err)
+	}
+}
+
+func TestSchemaResource_unitHardDelete(t *testing.T) {
+	ctx := context.Background()
+	_, client, r, s := testUnitSchemaResource(t)
+
+	m := testUnitSchemaModel("orders-value", testUnitSchemaV1)
+	m.HardDelete = types.BoolValue(true)
+	createResp := testUnitCreate(r, s, testUnitPlan(t, s, m))
+	testUnitNoErrors(t, "Create", createResp.Diagnostics)
+
+	testUnitNoErrors(t, "Delete", testUnitDelete(r, createResp.State).Diagnostics)
+	subjects, err := client.ListSubjects(ctx, "", true)
+	if err != nil {
+		t.Fatalf("ListSubjects() error = %v", err)
+	}
+	if slices.Contains(subjects, "orders-value") {
+		t.Errorf("ListSubjects() with deleted = %v, want the subject permanently deleted", subjects)
+	}
+}
+
+func TestSchemaResource_unitHardDeleteSoftDeleted(t *testing.T) {
+	ctx := context.Background()
+	_, client, r, s := testUnitSchemaResource(t)
+
+	m := testUnitSchemaModel("orders-value", testUnitSchemaV1)
+	m.HardDelete = types.BoolValue(true)
+	createResp := testUnitCreate(r, s, testUnitPlan(t, s, m))
+	testUnitNoErrors(t, "Create", createResp.Diagnostics)
+
+	// e.g. an earlier apply that failed after the soft delete
+	if err := client.DeleteSubject(ctx, "orders-value", false); err != nil {
+		t.Fatalf("DeleteSubject() error = %v", err)
+	}
+
+	testUnitNoErrors(t, "Delete", testUnitDelete(r, createResp.State).Diagnostics)
+	subjects, err := client.ListSubjects(ctx, "", true)
+	if err != nil {
+		t.Fatalf("ListSubjects() error = %v", err)
+	}
+	if slices.Contains(subjects, "orders-value") {
+		t.Errorf("ListSubjects() with deleted = %v, want the soft-deleted subject permanently deleted", subjects)
+	}
+}
+
+func TestSchemaResource_unitDrift(t *testing.T) {
+	ctx := context.Background()
+	_, client, r, s := testUnitSchemaResource(t)
+
+	createResp := testUnitCreate(r, s, testUnitPlan(t, s, testUnitSchemaModel("orders-value", testUnitSchemaV1)))
+	testUnitNoErrors(t, "Create", createResp.Diagnostics)
+
+	// A version registered outside of Terraform shows up in state
+	if _, err := client.RegisterSchema(ctx, "orders-value",
+		utils.NewSchemaRequest(testUnitSchemaV2, srclient.Avro, nil), false); err != nil {
+		t.Fatalf("RegisterSchema() error = %v", err)
+	}
+	readResp := testUnitRead(r, createResp.State)
+	testUnitNoErrors(t, "Read", readResp.Diagnostics)
+	if read := testUnitModel(t, readResp.State); read.Version.ValueInt64() != 2 ||
+		!utils.SchemasEqual("AVRO", read.Schema.ValueString(), testUnitSchemaV2) {
+		t.Errorf("Read state = version %d schema %s, want the out-of-band version 2", read.Version.ValueInt64(),
+			read.Schema.ValueString())
+	}
+
+	// A subject deleted outside of Terraform is removed from state
+	if err := client.DeleteSubject(ctx, "orders-value", false); err != nil {
+		t.Fatalf("DeleteSubject() error = %v", err)
+	}
+	readResp = testUnitRead(r, readResp.State)
+	testUnitNoErrors(t, "Read", readResp.Diagnostics)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("Read did not remove the deleted subject from state")
+	}
+
+	// Deleting it again is not an error
+	testUnitNoErrors(t, "Delete", testUnitDelete(r, createResp.State).Diagnostics)
+}
+
+func TestSchemaResource_unitErrors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// setup prepares the registry and returns the state to update, or
+		// a null state to create the resource
+		setup       func(t *testing.T, reg *fakeregistry.Registry, client *utils.Client) tfsdk.State
+		schema      string
+		wantSummary string
+		wantDetail  string
+	}{
+		{
+			name: "subject already exists",
+			setup: func(t *testing.T, _ *fakeregistry.Registry, client *utils.Client) tfsdk.State {
+				if _, err := client.RegisterSchema(ctx, "orders-value",
+					utils.NewSchemaRequest(testUnitSchemaV1, srclient.Avro, nil), false); err != nil {
+					t.Fatalf("RegisterSchema() error = %v", err)
+				}
+				return tfsdk.State{}
+			},
+			schema:      testUnitSchemaV1,
+			wantSummary: "Error creating schema",
+			wantDetail:  "terraform import",
+		},
+		{
+			name:        "invalid schema",
+			schema:      `{"type":"record","name":"Order"}`,
+			wantSummary: "Error creating schema",
+			wantDetail:  "error code 42201",
+		},
+		{
+			name: "server error",
+			setup: func(_ *testing.T, reg *fakeregistry.Registry, _ *utils.Client) tfsdk.State {
+				reg.FailNext(fakeregistry.ErrorCodeInternalServerError, "Error in the backend datastore")
+				return tfsdk.State{}
+			},
+			schema:      testUnitSchemaV1,
+			wantSummary: "Error creating schema",
+			wantDetail:  "Error in the backend datastore",
+		},
+		{
+			name:        "incompatible update",
+			setup:       testUnitCreated,
+			schema:      testUnitSchemaIncompatible,
+			wantSummary: "Error updating schema",
+			wantDetail:  "incompatible",
+		},
+		{
+			name: "read-only subject",
+			setup: func(t *testing.T, reg *fakeregistry.Registry, client *utils.Client) tfsdk.State {
+				state := testUnitCreated(t, reg, client)
+				if err := client.UpdateMode(ctx, "orders-value", utils.ModeReadOnly, false); err != nil {
+					t.Fatalf("UpdateMode() error = %v", err)
+				}
+				return state
+			},
+			schema:      testUnitSchemaV2,
+			wantSummary: "Error updating schema",
+			wantDetail:  "read-only",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg, client, r, s := testUnitSchemaResource(t)
+			var state tfsdk.State
+			if tt.setup != nil {
+				state = tt.setup(t, reg, client)
+			}
+
+			m := testUnitSchemaModel("orders-value", tt.schema)
+			var diags diag.Diagnostics
+			if state.Raw.IsNull() {
+				diags = testUnitCreate(r, s, testUnitPlan(t, s, m)).Diagnostics
+			} else {
+				m.ID = types.StringValue("orders-value")
+				diags = testUnitUpdate(r, s, testUnitPlan(t, s, m), state).Diagnostics
+			}
+
+			if !diags.HasError() {
+				t.Fatal("expected an error, got none")
+			}
+			got := diags.Errors()[0]
+			if got.Summary() != tt.wantSummary || !strings.Contains(got.Detail(), tt.wantDetail) {
+				t.Errorf("error = %q: %q, want %q containing %q", got.Summary(), got.Detail(), tt.wantSummary,
+					tt.wantDetail)
+			}
+		})
+	}
+}
+
+// testUnitCreated creates orders-value with a schema resource of its own and
+// returns its state.
+func testUnitCreated(t *testing.T, reg *fakeregistry.Registry, _ *utils.Client) tfsdk.State {
+	t.Helper()
+
+	client := utils.NewClient(reg.URL, reg.Client())
+	r := &schemaResource{client: client}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	resp := testUnitCreate(r, schemaResp.Schema, testUnitPlan(t, schemaResp.Schema,
+		testUnitSchemaModel("orders-value", testUnitSchemaV1)))
+	testUnitNoErrors(t, "Create", resp.Diagnostics)
+	return resp.State
+}
+
+// testUnitModifyPlan plans the change from state to the planned model, with
+// the model as the configuration.
+func testUnitModifyPlan(t *testing.T, r *schemaResource, s schema.Schema, plan schemaResourceModel,
+	state tfsdk.State) resource.ModifyPlanResponse {
+	t.Helper()
+
+	planned := testUnitPlan(t, s, plan)
+	resp := resource.ModifyPlanResponse{Plan: planned}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: planned.Raw},
+		Plan:   planned,
+		State:  state,
+	}, &resp)
+	return resp
+}
+
+func TestSchemaResource_unitFormattingBySchemaType(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		schema     string
+		planned    string
+		wantSame   bool
+	}{
+		{
+			name:       "avro whitespace and key order",
+			schemaType: "AVRO",
+			schema:     testUnitSchemaV1,
+			planned:    "{\n  \"name\": \"Order\",\n  \"type\": \"record\",\n  \"fields\": [{\"type\": \"string\", \"name\": \"id\"}]\n}",
+			wantSame:   true,
+		},
+		{
+			name:       "avro change",
+			schemaType: "AVRO",
+			schema:     testUnitSchemaV1,
+			planned:    testUnitSchemaV2,
+		},
+		{
+			name:       "protobuf whitespace and comments",
+			schemaType: "PROTOBUF",
+			schema:     `syntax = "proto3"; message Order { string id = 1; }`,
+			planned:    "syntax = \"proto3\";\n\n// An order.\nmessage Order {\n  string id = 1;\n}\n",
+			wantSame:   true,
+		},
+		{
+			name:       "protobuf field number change",
+			schemaType: "PROTOBUF",
+			schema:     `syntax = "proto3"; message Order { string id = 1; }`,
+			planned:    `syntax = "proto3"; message Order { string id = 2; }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, r, s := testUnitSchemaResource(t)
+
+			m := testUnitSchemaModel("orders-value", tt.schema)
+			m.SchemaType = types.StringValue(tt.schemaType)
+			createResp := testUnitCreate(r, s, testUnitPlan(t, s, m))
+			testUnitNoErrors(t, "Create", createResp.Diagnostics)
+			created := testUnitModel(t, createResp.State)
+
+			planned := testUnitSchemaModel("orders-value", tt.planned)
+			planned.SchemaType = types.StringValue(tt.schemaType)
+			planned.ID = created.ID
+			planResp := testUnitModifyPlan(t, r, s, planned, createResp.State)
+			testUnitNoErrors(t, "ModifyPlan", planResp.Diagnostics)
+
+			var got types.String
+			testUnitNoErrors(t, "reading the plan", planResp.Plan.GetAttribute(context.Background(),
+				path.Root("schema"), &got))
+			if same := got.Equal(created.Schema); same != tt.wantSame {
+				t.Errorf("planned schema %s, want the state schema kept = %t", got.ValueString(), tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestSchemaResource_unitPinnedVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int64
+		wantErr bool
+	}{
+		{name: "still set to the current version", version: 1, wantErr: true},
+		{name: "set to a new version", version: 2, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, client, r, s := testUnitSchemaResource(t)
+			if err := client.UpdateMode(context.Background(), "", utils.ModeImport, false); err != nil {
+				t.Fatalf("UpdateMode() error = %v", err)
+			}
+
+			m := testUnitSchemaModel("orders-value", testUnitSchemaV1)
+			m.Version = types.Int64Value(1)
+			createResp := testUnitCreate(r, s, testUnitPlan(t, s, m))
+			testUnitNoErrors(t, "Create", createResp.Diagnostics)
+
+			planned := testUnitSchemaModel("orders-value", testUnitSchemaV2)
+			planned.ID = types.StringValue("orders-value")
+			planned.Version = types.Int64Value(tt.version)
+			resp := testUnitModifyPlan(t, r, s, planned, createResp.State)
+			if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+				t.Fatalf("ModifyPlan() errors = %v, want errors %t", resp.Diagnostics.Errors(), tt.wantErr)
+			}
+			if tt.wantErr && resp.Diagnostics.Errors()[0].Summary() != "Version already registered" {
+				t.Errorf("ModifyPlan() error = %q, want Version already registered",
+					resp.Diagnostics.Errors()[0].Summary())
+			}
+		})
+	}
+}
+
+func TestSchemaResource_unitContext(t *testing.T) {
+	ctx := context.Background()
+	_, client, r, s := testUnitSchemaResource(t)
+
+	m := testUnitSchemaModel("orders-value", testUnitSchemaV1)
+	m.Context = types.StringValue(".team-a")
+	createResp := testUnitCreate(r, s, testUnitPlan(t, s, m))
+	testUnitNoErrors(t, "Create", createResp.Diagnostics)
+	if created := testUnitModel(t, createResp.State); created.ID.ValueString() != ":.team-a:orders-value" {
+		t.Errorf("Create id = %s, want :.team-a:orders-value", created.ID)
+	}
+
+	readResp := testUnitRead(r, createResp.State)
+	testUnitNoErrors(t, "Read", readResp.Diagnostics)
+	if readResp.State.Raw.IsNull() {
+		t.Fatal("Read removed the resource from state")
+	}
+
+	d := &contextsDataSource{}
+	var configureResp datasource.ConfigureResponse
+	d.Configure(ctx, datasource.ConfigureRequest{ProviderData: &providerData{client: client}}, &configureResp)
+	testUnitNoErrors(t, "Configure", configureResp.Diagnostics)
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	testUnitNoErrors(t, "Schema", schemaResp.Diagnostics)
+
+	values := tfsdk.State{Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	testUnitNoErrors(t, "setting the config", values.Set(ctx, contextsDataSourceModel{
+		ID:       types.StringNull(),
+		Contexts: types.ListNull(types.StringType),
+	}))
+	contextsResp := datasource.ReadResponse{State: values}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: values.Schema, Raw: values.Raw}}, &contextsResp)
+	testUnitNoErrors(t, "Read contexts", contextsResp.Diagnostics)
+
+	var contexts contextsDataSourceModel
+	testUnitNoErrors(t, "reading the contexts", contextsResp.State.Get(ctx, &contexts))
+	var names []string
+	testUnitNoErrors(t, "reading the context names", contexts.Contexts.ElementsAs(ctx, &names, false))
+	if !slices.Contains(names, ".") || !slices.Contains(names, ".team-a") {
+		t.Errorf("contexts = %v, want . and .team-a", names)
+	}
+}
+
+func TestSchemaResource_unitImportContext(t *testing.T) {
+	ctx := context.Background()
+	_, client, r, s := testUnitSchemaResource(t)
+	if _, err := client.RegisterSchema(ctx, ":.team-a:orders-value",
+		utils.NewSchemaRequest(testUnitSchemaV1, srclient.Avro, nil), false); err != nil {
+		t.Fatalf("RegisterSchema() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		providerCtx string
+		id          string
+		wantContext types.String
+	}{
+		{name: "qualified", id: ":.team-a:orders-value", wantContext: types.StringValue(".team-a")},
+		{name: "qualified with the provider context", providerCtx: ".team-a", id: ":.team-a:orders-value",
+			wantContext: types.StringNull()},
+		{name: "unqualified in the provider context", providerCtx: ".team-a", id: "orders-value",
+			wantContext: types.StringNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r.schemaContext = tt.providerCtx
+			resp := resource.ImportStateResponse{State: testUnitEmptyState(s)}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, &resp)
+			testUnitNoErrors(t, "ImportState", resp.Diagnostics)
+
+			m := testUnitModel(t, resp.State)
+			if m.Subject.ValueString() != "orders-value" || !m.Context.Equal(tt.wantContext) ||
+				m.ID.ValueString() != ":.team-a:orders-value" {
+				t.Errorf("ImportState state = subject %s context %s id %s, want orders-value, %s and "+
+					":.team-a:orders-value", m.Subject, m.Context, m.ID, tt.wantContext)
+			}
+		})
+	}
+}
+
+func TestSchemaResource_unitRemoveMetadata(t *testing.T) {
+	ctx := context.Background()
+	_, client, r, s := testUnitSchemaResource(t)
+
+	m := testUnitSchemaModel("orders-value", testUnitSchemaV1)
+	m.Metadata = types.ObjectValueMust(utils.MetadataAttrTypes, map[string]attr.Value{
+		"tags":       types.MapNull(types.SetType{ElemType: types.StringType}),
+		"properties": types.MapValueMust(types.StringType, map[string]attr.Value{"owner": types.StringValue("team-a")}),
+		"sensitive":  types.SetNull(types.StringType),
+	})
+	createResp := testUnitCreate(r, s, testUnitPlan(t, s, m))
+	testUnitNoErrors(t, "Create", createResp.Diagnostics)
+
+	// Removing the metadata from the configuration plans a new version
+	planned := testUnitSchemaModel("orders-value", testUnitSchemaV1)
+	planned.ID = types.StringValue("orders-value")
+	planResp := testUnitModifyPlan(t, r, s, planned, createResp.State)
+	testUnitNoErrors(t, "ModifyPlan", planResp.Diagnostics)
+	if plan := testUnitModel(t, tfsdk.State(planResp.Plan)); !plan.Metadata.IsNull() || !plan.Version.IsUnknown() {
+		t.Fatalf("ModifyPlan metadata = %s version = %s, want null metadata and a new version",
+			plan.Metadata, plan.Version)
+	}
+
+	updateResp := testUnitUpdate(r, s, planResp.Plan, createResp.State)
+	testUnitNoErrors(t, "Update", updateResp.Diagnostics)
+	if updated := testUnitModel(t, updateResp.State); !updated.Metadata.IsNull() || updated.Version.ValueInt64() != 2 {
+		t.Errorf("Update state metadata = %s version = %d, want null metadata and version 2",
+			updated.Metadata, updated.Version.ValueInt64())
+	}
+
+	latest, err := client.GetSubjectVersion(ctx, "orders-value", "latest", false)
+	if err != nil {
+		t.Fatalf("GetSubjectVersion() error = %v", err)
+	}
+	if latest.Version != 2 || !utils.MetadataEqual(latest.Metadata, nil) {
+		t.Errorf("latest version = %d with metadata %+v, want version 2 without metadata", latest.Version,
+			latest.Metadata)
+	}
+
+	readResp := testUnitRead(r, updateResp.State)
+	testUnitNoErrors(t, "Read", readResp.Diagnostics)
+	if read := testUnitModel(t, readResp.State); !read.Metadata.IsNull() {
+		t.Errorf("Read metadata = %s, want null", read.Metadata)
+	}
+}
+
+func TestSchemaResource_unitNormalize(t *testing.T) {
+	_, _, r, s := testUnitSchemaResource(t)
+
+	configured := "{\n  \"type\": \"record\",\n  \"name\": \"Order\",\n  \"fields\": [{\"name\": \"id\", \"type\": \"string\"}]\n}\n"
+	normalized := `{"fields":[{"name":"id","type":"string"}],"name":"Order","type":"record"}`
+	m := testUnitSchemaModel("orders-value", configured)
+	m.Normalize = types.BoolValue(true)
+	createResp := testUnitCreate(r, s, testUnitPlan(t, s, m))
+	testUnitNoErrors(t, "Create", createResp.Diagnostics)
+	created := testUnitModel(t, createResp.State)
+	if created.Schema.ValueString() != configured || created.RegisteredSchema.ValueString() != normalized {
+		t.Errorf("Create state = schema %q registered_schema %q, want the configured and the normalized schema",
+			created.Schema.ValueString(), created.RegisteredSchema.ValueString())
+	}
+
+	readResp := testUnitRead(r, createResp.State)
+	testUnitNoErrors(t, "Read", readResp.Diagnostics)
+	if read := testUnitModel(t, readResp.State); read.Schema.ValueString() != configured {
+		t.Errorf("Read schema = %q, want the configured schema %q", read.Schema.ValueString(), configured)
+	}
+
+	// A copy that only differs in formatting plans no change
+	planned := testUnitSchemaModel("orders-value", testUnitSchemaV1)
+	planned.ID = created.ID
+	planned.Normalize = types.BoolValue(true)
+	planned.CompatibilityLevel = created.CompatibilityLevel
+	planResp := testUnitModifyPlan(t, r, s, planned, readResp.State)
+	testUnitNoErrors(t, "ModifyPlan", planResp.Diagnostics)
+	var plan schemaResourceModel
+	testUnitNoErrors(t, "reading the plan", planResp.Plan.Get(context.Background(), &plan))
+	if plan.Schema.ValueString() != configured || !plan.Version.Equal(created.Version) {
+		t.Errorf("ModifyPlan plan = schema %q version %s, want the state unchanged", plan.Schema.ValueString(),
+			plan.Version)
+	}
+}
@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &subjectConfigResource{}
+	_ resource.ResourceWithConfigure   = &subjectConfigResource{}
+	_ resource.ResourceWithImportState = &subjectConfigResource{}
+)
+
+// NewSubjectConfigResource is a helper function to simplify the provider implementation.
+func NewSubjectConfigResource() resource.Resource {
+	return &subjectConfigResource{}
+}
+
+// subjectConfigResource is the resource implementation.
+type subjectConfigResource struct {
+	client        *utils.Client
+	schemaContext string
+}
+
+// subjectConfigResourceModel describes the resource data model.
+type subjectConfigResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Subject            types.String `tfsdk:"subject"`
+	Context            types.String `tfsdk:"context"`
+	CompatibilityLevel types.String `tfsdk:"compatibility_level"`
+	Normalize          types.Bool   `tfsdk:"normalize"`
+	Alias              types.String `tfsdk:"alias"`
+	CompatibilityGroup types.String `tfsdk:"compatibility_group"`
+	DefaultMetadata    types.Object `tfsdk:"default_metadata"`
+	OverrideMetadata   types.Object `tfsdk:"override_metadata"`
+	DefaultRuleSet     types.Object `tfsdk:"default_rule_set"`
+	OverrideRuleSet    types.Object `tfsdk:"override_rule_set"`
+}
+
+// Metadata returns the resource type name.
+func (r *subjectConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subject_config"
+}
+
+// Schema defines the schema for the resource.
+func (r *subjectConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Subject config resource. Manages the configuration of a subject in the Schema Registry. " +
+			"Destroying the resource reverts the subject to the global configuration. Do not combine with " +
+			"`compatibility_level` on a `schemaregistry_schema` resource for the same subject.",
+		Description: "Manages the configuration of a subject in the Schema Registry.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The subject the configuration applies to, qualified with its schema context when it " +
+					"is not the default context.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subject": schema.StringAttribute{
+				Description: "The subject to configure.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.LengthAtMost(249),
+					subjectNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"context": schema.StringAttribute{
+				Description: contextDescription,
+				Optional:    true,
+				Validators: []validator.String{
+					contextNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"compatibility_level": schema.StringAttribute{
+				Description: "The compatibility level of the subject.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"NONE",
+						"BACKWARD",
+						"BACKWARD_TRANSITIVE",
+						"FORWARD",
+						"FORWARD_TRANSITIVE",
+						"FULL",
+						"FULL_TRANSITIVE",
+					),
+				},
+			},
+			"normalize": schema.BoolAttribute{
+				Description: "Whether schemas registered under the subject are normalized.",
+				Optional:    true,
+			},
+			"alias": schema.StringAttribute{
+				Description: "Another subject this subject is an alias for.",
+				Optional:    true,
+			},
+			"compatibility_group": schema.StringAttribute{
+				Description: "The metadata property whose value partitions versions into compatibility groups.",
+				Optional:    true,
+			},
+			"default_metadata": metadataAttribute(
+				"Metadata applied to new schemas registered under the subject that do not specify their own."),
+			"override_metadata": metadataAttribute(
+				"Metadata that overrides the metadata of new schemas registered under the subject."),
+			"default_rule_set": ruleSetAttribute(
+				"Rule set applied to new schemas registered under the subject that do not specify their own."),
+			"override_rule_set": ruleSetAttribute(
+				"Rule set that overrides the rule set of new schemas registered under the subject."),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *subjectConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.schemaContext = data.schemaContext
+}
+
+func (r *subjectConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan subjectConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, diags := plan.toConfig(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subject := r.qualifiedSubject(plan)
+	if err := r.client.UpdateConfig(ctx, subject, config); err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Creating Subject Config",
+			fmt.Sprintf("Could not set config for subject %s", subject), err)
+		return
+	}
+
+	plan.ID = types.StringValue(subject)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *subjectConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state subjectConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subject := r.qualifiedSubject(state)
+	config, err := r.client.GetConfig(ctx, subject, false)
+	if err != nil {
+		if isConfigNotFound(err) {
+			tflog.Warn(ctx, "Subject config not found in Schema Registry, removing from state", map[string]interface{}{
+				"subject": subject,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addRegistryError(&resp.Diagnostics, "Error Reading Subject Config",
+			fmt.Sprintf("Could not read config for subject %s", subject), err)
+		return
+	}
+
+	state.ID = types.StringValue(subject)
+	resp.Diagnostics.Append(state.fromConfig(ctx, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *subjectConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state subjectConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, diags := plan.toConfig(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// PUT only changes the fields it is given, so a removed setting is
+	// cleared by deleting the subject config before writing the rest back
+	subject := r.qualifiedSubject(plan)
+	if state.hasRemovedSettings(plan) {
+		if err := r.client.DeleteConfig(ctx, subject); err != nil && !isConfigNotFound(err) {
+			addRegistryError(&resp.Diagnostics, "Error Updating Subject Config",
+				fmt.Sprintf("Could not clear config for subject %s", subject), err)
+			return
+		}
+	}
+
+	if err := r.client.UpdateConfig(ctx, subject, config); err != nil {
+		addRegistryError(&resp.Diagnostics, "Error Updating Subject Config",
+			fmt.Sprintf("Could not set config for subject %s", subject), err)
+		return
+	}
+
+	plan.ID = types.StringValue(subject)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *subjectConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state subjectConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleting the subject config reverts the subject to the global config
+	subject := r.qualifiedSubject(state)
+	err := r.client.DeleteConfig(ctx, subject)
+	if err != nil && !isConfigNotFound(err) {
+		addRegistryError(&resp.Diagnostics, "Error Deleting Subject Config",
+			fmt.Sprintf("Could not delete config for subject %s", subject), err)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *subjectConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+	subject, schemaContext := splitImportID(req.ID, r.schemaContext)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subject"), subject)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("context"), schemaContext)...)
+}
+
+// qualifiedSubject returns the subject of m qualified with its schema
+// context, as used in registry requests.
+func (r *subjectConfigResource) qualifiedSubject(m subjectConfigResourceModel) string {
+	return qualifySubject(m.Context, r.schemaContext, m.Subject.ValueString())
+}
+
+// toConfig converts the model into a registry config.
+func (m subjectConfigResourceModel) toConfig(ctx context.Context) (*utils.Config, diag.Diagnostics) {
+	var diags, d diag.Diagnostics
+	config := &utils.Config{
+		CompatibilityLevel: m.CompatibilityLevel.ValueString(),
+		Normalize:          m.Normalize.ValueBoolPointer(),
+		Alias:              m.Alias.ValueString(),
+		CompatibilityGroup: m.CompatibilityGroup.ValueString(),
+	}
+
+	config.DefaultMetadata, d = utils.ToRegistryMetadata(ctx, m.DefaultMetadata)
+	diags.Append(d...)
+	config.OverrideMetadata, d = utils.ToRegistryMetadata(ctx, m.OverrideMetadata)
+	diags.Append(d...)
+	config.DefaultRuleSet, d = utils.ToRegistryRuleSet(ctx, m.DefaultRuleSet)
+	diags.Append(d...)
+	config.OverrideRuleSet, d = utils.ToRegistryRuleSet(ctx, m.OverrideRuleSet)
+	diags.Append(d...)
+
+	return config, diags
+}
+
+// fromConfig updates the model from a registry config.
+func (m *subjectConfigResourceModel) fromConfig(ctx context.Context, config *utils.Config) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	m.CompatibilityLevel = utils.OptionalString(config.CompatibilityLevel)
+	m.Normalize = types.BoolPointerValue(config.Normalize)
+	m.Alias = utils.OptionalString(config.Alias)
+	m.CompatibilityGroup = utils.OptionalString(config.CompatibilityGroup)
+
+	m.DefaultMetadata, d = utils.FromRegistryMetadata(ctx, config.DefaultMetadata)
+	diags.Append(d...)
+	m.OverrideMetadata, d = utils.FromRegistryMetadata(ctx, config.OverrideMetadata)
+	diags.Append(d...)
+	m.DefaultRuleSet, d = utils.FromRegistryRuleSet(ctx, config.DefaultRuleSet)
+	diags.Append(d...)
+	m.OverrideRuleSet, d = utils.FromRegistryRuleSet(ctx, config.OverrideRuleSet)
+	diags.Append(d...)
+
+	return diags
+}
+
+// hasRemovedSettings reports whether a setting present in m is absent from plan.
+func (m subjectConfigResourceModel) hasRemovedSettings(plan subjectConfigResourceModel) bool {
+	removed := func(state, planned interface{ IsNull() bool }) bool {
+		return !state.IsNull() && planned.IsNull()
+	}
+
+	return removed(m.CompatibilityLevel, plan.CompatibilityLevel) ||
+		removed(m.Normalize, plan.Normalize) ||
+		removed(m.Alias, plan.Alias) ||
+		removed(m.CompatibilityGroup, plan.CompatibilityGroup) ||
+		removed(m.DefaultMetadata, plan.DefaultMetadata) ||
+		removed(m.OverrideMetadata, plan.OverrideMetadata) ||
+		removed(m.DefaultRuleSet, plan.DefaultRuleSet) ||
+		removed(m.OverrideRuleSet, plan.OverrideRuleSet)
+}
+
+// isConfigNotFound reports whether err means the subject has no config of its own.
+func isConfigNotFound(err error) bool {
+	return utils.IsNotFound(err) || errors.Is(err, utils.ErrConfigNotFound)
+}
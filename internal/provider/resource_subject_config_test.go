@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSubjectConfigResource_basic(t *testing.T) {
+	subjectName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "schemaregistry_subject_config.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSubjectConfigDestroy(subjectName),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSubjectConfigResourceConfig(subjectName, "BACKWARD"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", subjectName),
+					resource.TestCheckResourceAttr(resourceName, "subject", subjectName),
+					resource.TestCheckResourceAttr(resourceName, "compatibility_level", "BACKWARD"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSubjectConfigResourceConfig(subjectName, "FULL_TRANSITIVE"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "subject", subjectName),
+					resource.TestCheckResourceAttr(resourceName, "compatibility_level", "FULL_TRANSITIVE"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     subjectName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckSubjectConfigDestroy verifies the subject no longer has a
+// compatibility level of its own once the resource is destroyed.
+func testAccCheckSubjectConfigDestroy(subject string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		level, err := testAccClient().GetCompatibilityLevel(subject, false)
+		if err == nil && level != nil {
+			return fmt.Errorf("subject %s still has compatibility level %s", subject, level.String())
+		}
+		return nil
+	}
+}
+
+func testAccSubjectConfigResourceConfig(subject, compatibilityLevel string) string {
+	const template = `
+resource "schemaregistry_subject_config" "test" {
+  subject             = "%s"
+  compatibility_level = "%s"
+}
+`
+	return ConfigCompose(testAccSchemaResourceConfig_base(),
+		fmt.Sprintf(template, subject, compatibilityLevel),
+	)
+}
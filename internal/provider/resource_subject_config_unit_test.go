@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
+)
+
+// testUnitSubjectConfigResource returns a subject config resource configured
+// against an in-memory registry, and a client for inspecting it.
+func testUnitSubjectConfigResource(t *testing.T) (*utils.Client, *subjectConfigResource, schema.Schema) {
+	t.Helper()
+	ctx := context.Background()
+
+	_, client := testUnitClient(t)
+
+	r := &subjectConfigResource{}
+	var configureResp resource.ConfigureResponse
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: &providerData{client: client}}, &configureResp)
+	testUnitNoErrors(t, "Configure", configureResp.Diagnostics)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	testUnitNoErrors(t, "Schema", schemaResp.Diagnostics)
+
+	return client, r, schemaResp.Schema
+}
+
+// testUnitSubjectConfigModel returns a planned model that sets nothing but
+// the subject.
+func testUnitSubjectConfigModel(subject string) subjectConfigResourceModel {
+	return subjectConfigResourceModel{
+		ID:                 types.StringUnknown(),
+		Subject:            types.StringValue(subject),
+		Context:            types.StringNull(),
+		CompatibilityLevel: types.StringNull(),
+		Normalize:          types.BoolNull(),
+		Alias:              types.StringNull(),
+		CompatibilityGroup: types.StringNull(),
+		DefaultMetadata:    types.ObjectNull(utils.MetadataAttrTypes),
+		OverrideMetadata:   types.ObjectNull(utils.MetadataAttrTypes),
+		DefaultRuleSet:     types.ObjectNull(utils.RuleSetAttrTypes),
+		OverrideRuleSet:    types.ObjectNull(utils.RuleSetAttrTypes),
+	}
+}
+
+func TestSubjectConfigResource_unitRemoveSettings(t *testing.T) {
+	ctx := context.Background()
+	client, r, s := testUnitSubjectConfigResource(t)
+
+	m := testUnitSubjectConfigModel("orders-value")
+	m.CompatibilityLevel = types.StringValue("FULL")
+	m.Normalize = types.BoolValue(true)
+	m.Alias = types.StringValue("orders")
+	m.DefaultMetadata = types.ObjectValueMust(utils.MetadataAttrTypes, map[string]attr.Value{
+		"tags":       types.MapNull(types.SetType{ElemType: types.StringType}),
+		"properties": types.MapValueMust(types.StringType, map[string]attr.Value{"owner": types.StringValue("team-a")}),
+		"sensitive":  types.SetNull(types.StringType),
+	})
+	createResp := resource.CreateResponse{State: testUnitEmptyState(s)}
+	r.Create(ctx, resource.CreateRequest{Plan: testUnitPlan(t, s, m)}, &createResp)
+	testUnitNoErrors(t, "Create", createResp.Diagnostics)
+
+	// Remove every setting but keep an empty default_metadata block
+	planned := testUnitSubjectConfigModel("orders-value")
+	planned.ID = types.StringValue("orders-value")
+	planned.DefaultMetadata = types.ObjectValueMust(utils.MetadataAttrTypes, map[string]attr.Value{
+		"tags":       types.MapNull(types.SetType{ElemType: types.StringType}),
+		"properties": types.MapNull(types.StringType),
+		"sensitive":  types.SetNull(types.StringType),
+	})
+	updateResp := resource.UpdateResponse{State: testUnitEmptyState(s)}
+	r.Update(ctx, resource.UpdateRequest{Plan: testUnitPlan(t, s, planned), State: createResp.State}, &updateResp)
+	testUnitNoErrors(t, "Update", updateResp.Diagnostics)
+
+	config, err := client.GetConfig(ctx, "orders-value", false)
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if config.CompatibilityLevel != "" || config.Normalize != nil || config.Alias != "" ||
+		!utils.MetadataEqual(config.DefaultMetadata, nil) {
+		t.Errorf("subject config = %+v, want every setting but default_metadata cleared", config)
+	}
+
+	readResp := resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	testUnitNoErrors(t, "Read", readResp.Diagnostics)
+	var read subjectConfigResourceModel
+	testUnitNoErrors(t, "reading the state", readResp.State.Get(ctx, &read))
+	if !read.CompatibilityLevel.IsNull() || !read.Normalize.IsNull() || !read.Alias.IsNull() ||
+		!read.DefaultMetadata.Equal(planned.DefaultMetadata) {
+		t.Errorf("Read state = compatibility %s normalize %s alias %s default_metadata %s, want the planned values",
+			read.CompatibilityLevel, read.Normalize, read.Alias, read.DefaultMetadata)
+	}
+}
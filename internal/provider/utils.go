@@ -2,14 +2,20 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/cookiejar"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/cultureamp/terraform-provider-schemaregistry/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"golang.org/x/sync/semaphore"
 )
 
 // buildRetryDelays returns n exponential backoff durations starting from base.
@@ -21,6 +27,37 @@ func buildRetryDelays(n int, base time.Duration) []time.Duration {
 	return delays
 }
 
+// buildRetryPolicy builds the retry policy applied to every registry request
+// from the provider configuration.
+func buildRetryPolicy(ctx context.Context, config ProviderModel, maxRetries int) (utils.RetryPolicy,
+	diag.Diagnostics) {
+	policy := utils.RetryPolicy{
+		MaxRetries: maxRetries,
+		BaseDelay:  retryBaseInterval,
+		MaxWait:    defaultRetryMaxWait,
+	}
+
+	var diags diag.Diagnostics
+	if !config.RetryMaxWait.IsNull() {
+		maxWait, err := time.ParseDuration(config.RetryMaxWait.ValueString())
+		if err != nil {
+			diags.AddError("Invalid retry configuration", err.Error())
+			return policy, diags
+		}
+		policy.MaxWait = maxWait
+	}
+
+	if !config.RetryableStatusCodes.IsNull() {
+		var codes []int64
+		diags.Append(config.RetryableStatusCodes.ElementsAs(ctx, &codes, false)...)
+		policy.RetryableStatusCodes = make([]int, 0, len(codes))
+		for _, code := range codes {
+			policy.RetryableStatusCodes = append(policy.RetryableStatusCodes, int(code))
+		}
+	}
+	return policy, diags
+}
+
 // getEnvOrDefault returns the value of the configuration or the environment variable.
 func getEnvOrDefault(envVar, defaultValue string) string {
 	if value, exists := os.LookupEnv(envVar); exists && value != "" {
@@ -29,57 +66,295 @@ func getEnvOrDefault(envVar, defaultValue string) string {
 	return defaultValue
 }
 
-// ConfigCompose can be called to concatenate multiple strings to build test configurations.
-func ConfigCompose(config ...string) string {
-	var str strings.Builder
-
-	for _, conf := range config {
-		str.WriteString(conf)
+// getEnvBoolOrDefault returns the value of the environment variable parsed as
+// a bool, or the configuration when the variable is not set.
+func getEnvBoolOrDefault(envVar string, defaultValue bool) (bool, error) {
+	value, exists := os.LookupEnv(envVar)
+	if !exists || value == "" {
+		return defaultValue, nil
 	}
 
-	return str.String()
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q for %s: %w", value, envVar, err)
+	}
+	return parsed, nil
 }
 
-// ValidateSchemaString compares two JSON schema strings for semantic equality.
-// It returns an error if the schemas are not semantically equivalent or if
-// validation fails due to parsing errors.
-func ValidateSchemaString(expected, actual string) error {
-	ctx := context.Background()
+// buildHTTPClient builds the HTTP client of the registry client from the
+// provider configuration, and returns the number of retries it makes.
+// basicAuth reports whether basic authentication credentials are configured,
+// which cannot be combined with bearer tokens.
+func buildHTTPClient(ctx context.Context, config ProviderModel, basicAuth bool) (*http.Client, int,
+	diag.Diagnostics) {
+	var diags diag.Diagnostics
 
-	expectedNormalized := jsontypes.NewNormalizedValue(expected)
-	actualNormalized := jsontypes.NewNormalizedValue(actual)
+	// A cookie jar keeps load balancer sessions sticky
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		diags.AddError("Failed to create client", err.Error())
+		return nil, 0, diags
+	}
+	requestTimeout := defaultTimeout
+	if !config.RequestTimeout.IsNull() {
+		requestTimeout, err = time.ParseDuration(config.RequestTimeout.ValueString())
+		if err != nil {
+			diags.AddError("Invalid request timeout", err.Error())
+			return nil, 0, diags
+		}
+	}
 
-	equal, diags := expectedNormalized.StringSemanticEquals(ctx, actualNormalized)
+	baseTransport, d := buildTransport(config)
+	diags.Append(d...)
+	maxRetries := defaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	retryPolicy, d := buildRetryPolicy(ctx, config, maxRetries)
+	diags.Append(d...)
+	headers, d := buildHeaders(ctx, config)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, 0, diags
+	}
 
-	// Check for validation errors first
+	var transport http.RoundTripper = &utils.RetryTransport{Base: buildLimitTransport(config, baseTransport),
+		Policy: retryPolicy}
+	if len(headers) > 0 {
+		transport = &utils.HeaderTransport{Base: transport, Headers: headers}
+	}
+
+	// Token endpoints are reached through the same proxy and TLS settings as
+	// the registry, but without its headers and limits
+	transport, d = buildAuthTransport(ctx, config, transport, basicAuth, &http.Client{
+		Timeout:   requestTimeout,
+		Transport: &utils.RetryTransport{Base: baseTransport, Policy: retryPolicy},
+	})
+	diags.Append(d...)
 	if diags.HasError() {
-		return fmt.Errorf("schema validation failed: %w", formatDiagnostics(diags))
+		return nil, 0, diags
 	}
 
-	// Check for semantic inequality
-	if !equal {
-		return fmt.Errorf("schemas are not semantically equal:\nexpected: %s\nactual: %s", expected, actual)
+	return &http.Client{Timeout: requestTimeout, Jar: jar, Transport: transport}, maxRetries, diags
+}
+
+// buildLimitTransport wraps base with the client-side rate and concurrency
+// limits of the provider configuration. The limits apply to every attempt,
+// including retries.
+func buildLimitTransport(config ProviderModel, base http.RoundTripper) *utils.LimitTransport {
+	transport := &utils.LimitTransport{Base: base}
+	if !config.RequestsPerSecond.IsNull() {
+		transport.Limiter = utils.NewRateLimiter(config.RequestsPerSecond.ValueFloat64())
+	}
+	if !config.MaxConcurrentRequests.IsNull() {
+		transport.Concurrency = semaphore.NewWeighted(config.MaxConcurrentRequests.ValueInt64())
 	}
+	return transport
+}
 
-	return nil
+// buildAuthTransport wraps transport with bearer token authentication when
+// bearer_token or oauth is configured. Tokens are fetched with tokenClient.
+func buildAuthTransport(ctx context.Context, config ProviderModel, transport http.RoundTripper, basicAuth bool,
+	tokenClient *http.Client) (http.RoundTripper, diag.Diagnostics) {
+	tokenSource, diags := buildTokenSource(ctx, config, tokenClient)
+	if diags.HasError() || tokenSource == nil {
+		return transport, diags
+	}
+	if basicAuth {
+		diags.AddError("Conflicting credentials", "Basic authentication credentials cannot be "+
+			"combined with bearer_token or oauth.")
+		return nil, diags
+	}
+	return &utils.BearerTransport{Base: transport, Source: tokenSource}, diags
+}
+
+// buildTransport builds the transport of the registry client from the TLS and
+// proxy settings of the provider configuration and environment.
+func buildTransport(config ProviderModel) (*http.Transport, diag.Diagnostics) {
+	tlsConfig, diags := buildTLSConfig(config)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	proxy, err := utils.NewProxyFunc(getEnvOrDefault("SCHEMA_REGISTRY_PROXY_URL", config.ProxyURL.ValueString()),
+		getEnvOrDefault("SCHEMA_REGISTRY_NO_PROXY", config.NoProxy.ValueString()))
+	if err != nil {
+		diags.AddError("Invalid proxy configuration", err.Error())
+		return nil, diags
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return transport, diags
 }
 
-// formatDiagnostics converts diagnostic errors into a single error message.
-func formatDiagnostics(diags diag.Diagnostics) error {
-	if !diags.HasError() {
-		return nil
+// buildHeaders returns the headers sent with every registry request. The
+// SCHEMA_REGISTRY_HEADERS environment variable holds comma separated
+// name=value pairs.
+func buildHeaders(ctx context.Context, config ProviderModel) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value := getEnvOrDefault("SCHEMA_REGISTRY_HEADERS", ""); value != "" {
+		headers := map[string]string{}
+		for _, pair := range strings.Split(value, ",") {
+			name, val, ok := strings.Cut(pair, "=")
+			name = strings.TrimSpace(name)
+			if !ok || name == "" {
+				diags.AddError("Invalid headers", fmt.Sprintf("Invalid header %q in SCHEMA_REGISTRY_HEADERS, "+
+					"expected name=value.", pair))
+				return nil, diags
+			}
+			headers[name] = strings.TrimSpace(val)
+		}
+		return headers, diags
+	}
+
+	var headers map[string]string
+	diags.Append(config.Headers.ElementsAs(ctx, &headers, false)...)
+	return headers, diags
+}
+
+// buildTLSConfig builds the TLS configuration for the registry client from the
+// provider configuration and environment. It returns nil when TLS is not
+// configured, so that the default configuration is used.
+func buildTLSConfig(config ProviderModel) (*tls.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	insecureSkipVerify, err := getEnvBoolOrDefault("SCHEMA_REGISTRY_INSECURE_SKIP_VERIFY",
+		config.InsecureSkipVerify.ValueBool())
+	if err != nil {
+		diags.AddError("Invalid TLS configuration", err.Error())
+		return nil, diags
 	}
 
-	var messages []string
-	for _, d := range diags.Errors() {
-		if detail := d.Detail(); detail != "" {
-			messages = append(messages, fmt.Sprintf("%s: %s", d.Summary(), detail))
-		} else {
-			messages = append(messages, d.Summary())
+	tlsConfig, err := utils.NewTLSConfig(utils.TLSOptions{
+		CACert:             getEnvOrDefault("SCHEMA_REGISTRY_CA_CERT", config.CACert.ValueString()),
+		CACertFile:         getEnvOrDefault("SCHEMA_REGISTRY_CA_CERT_FILE", config.CACertFile.ValueString()),
+		ClientCert:         getEnvOrDefault("SCHEMA_REGISTRY_CLIENT_CERT", config.ClientCert.ValueString()),
+		ClientKey:          getEnvOrDefault("SCHEMA_REGISTRY_CLIENT_KEY", config.ClientKey.ValueString()),
+		ServerName:         getEnvOrDefault("SCHEMA_REGISTRY_TLS_SERVER_NAME", config.TLSServerName.ValueString()),
+		InsecureSkipVerify: insecureSkipVerify,
+	})
+	if err != nil {
+		diags.AddError("Invalid TLS configuration", err.Error())
+		return nil, diags
+	}
+	return tlsConfig, diags
+}
+
+// buildTokenSource returns the source of bearer tokens for the registry
+// client, or nil when neither bearer_token nor oauth is configured. Tokens are
+// fetched from the OAuth token endpoint with httpClient.
+func buildTokenSource(ctx context.Context, config ProviderModel, httpClient *http.Client) (utils.TokenSource,
+	diag.Diagnostics) {
+	if token := getEnvOrDefault("SCHEMA_REGISTRY_BEARER_TOKEN", config.BearerToken.ValueString()); token != "" {
+		if !config.OAuth.IsNull() {
+			var diags diag.Diagnostics
+			diags.AddError("Conflicting credentials", "Only one of bearer_token and oauth may be set.")
+			return nil, diags
 		}
+		return utils.StaticToken(token), nil
+	}
+	if config.OAuth.IsNull() || config.OAuth.IsUnknown() {
+		return nil, nil
+	}
+
+	var oauth oauthModel
+	diags := config.OAuth.As(ctx, &oauth, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+	oauthConfig := utils.OAuthConfig{
+		TokenURL:     oauth.TokenURL.ValueString(),
+		ClientID:     oauth.ClientID.ValueString(),
+		ClientSecret: oauth.ClientSecret.ValueString(),
+		Audience:     oauth.Audience.ValueString(),
+	}
+	diags.Append(oauth.Scopes.ElementsAs(ctx, &oauthConfig.Scopes, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return utils.NewOAuthTokenSource(oauthConfig, httpClient), diags
+}
+
+// addRegistryError appends an error diagnostic for a failed Schema Registry
+// call. The detail explains what kind of failure the registry reported and
+// what can be done about it, followed by the registry's own message.
+func addRegistryError(diags *diag.Diagnostics, summary, action string, err error) {
+	err = utils.ClassifyError(err)
+
+	detail := fmt.Sprintf("%s: %s", action, err)
+	if hint := registryErrorHint(err); hint != "" {
+		detail += "\n\n" + hint
 	}
 
-	return errors.New(strings.Join(messages, "; "))
+	diags.AddError(summary, detail)
+}
+
+// registryErrorHint returns an actionable explanation for a classified
+// registry error, or an empty string when there is nothing specific to add.
+func registryErrorHint(err error) string {
+	switch {
+	case errors.Is(err, utils.ErrSubjectNotFound):
+		return "The subject does not exist in the Schema Registry. Check the subject name, or import the " +
+			"subject if it is managed elsewhere."
+	case errors.Is(err, utils.ErrVersionNotFound):
+		return "The requested version does not exist for this subject. It may have been deleted."
+	case errors.Is(err, utils.ErrSchemaNotFound):
+		return "The schema does not exist in the Schema Registry. Check that referenced subjects and " +
+			"versions exist."
+	case errors.Is(err, utils.ErrIncompatibleSchema):
+		return "The schema is not compatible with earlier versions under the subject's compatibility " +
+			"level. Change the schema so it is compatible, or relax compatibility_level."
+	case errors.Is(err, utils.ErrInvalidSchema):
+		return "The Schema Registry rejected the schema as invalid. Check the schema definition, " +
+			"schema_type and references."
+	case errors.Is(err, utils.ErrInvalidCompatibilityLevel):
+		return "The Schema Registry does not accept this compatibility level."
+	case errors.Is(err, utils.ErrInvalidMode):
+		return "The Schema Registry does not accept this mode, or the subject has schemas and the mode " +
+			"change requires force."
+	case errors.Is(err, utils.ErrOperationNotPermitted):
+		return "The subject or registry mode does not allow this operation. Writes are rejected in READONLY " +
+			"mode, and explicit schema IDs are only accepted in IMPORT mode. Check schemaregistry_mode."
+	case errors.Is(err, utils.ErrReferenceExists):
+		return "Other schemas still reference this schema. Remove or update the referencing schemas first; " +
+			"the schemaregistry_schema_referenced_by data source lists them."
+	case errors.Is(err, utils.ErrUnauthorized):
+		return "Authentication with the Schema Registry failed. Check the provider credentials: username and " +
+			"password, bearer token or OAuth client credentials."
+	case errors.Is(err, utils.ErrForbidden):
+		return "The configured credentials are not permitted to perform this operation."
+	case errors.Is(err, utils.ErrServerError):
+		return "The Schema Registry returned a server error. This is usually transient; retry the operation."
+	default:
+		return ""
+	}
+}
+
+// ConfigCompose can be called to concatenate multiple strings to build test configurations.
+func ConfigCompose(config ...string) string {
+	var str strings.Builder
+
+	for _, conf := range config {
+		str.WriteString(conf)
+	}
+
+	return str.String()
+}
+
+// ValidateSchemaString compares two schema strings of the given schema type
+// for semantic equality. It returns an error if the schemas are not
+// semantically equivalent.
+func ValidateSchemaString(schemaType, expected, actual string) error {
+	if !utils.SchemasEqual(schemaType, expected, actual) {
+		return fmt.Errorf("schemas are not semantically equal:\nexpected: %s\nactual: %s", expected, actual)
+	}
+
+	return nil
 }
 
 // NormalizeSchemaString removes all whitespace and newlines from a schema string
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// contextNameRegex matches schema context names, with or without their
+// leading dot.
+var contextNameRegex = regexp.MustCompile(`^\.?[A-Za-z0-9._-]*$`)
+
+// contextNameValidator checks that a string attribute is a schema context name.
+func contextNameValidator() validator.String {
+	return stringvalidator.RegexMatches(contextNameRegex,
+		"must be a schema context name such as `.team-a`, using only letters, digits, '.', '_' and '-'")
+}
+
+// subjectNameRegex matches subject names, optionally qualified with a schema
+// context.
+var subjectNameRegex = regexp.MustCompile(`^(:\.[A-Za-z0-9._-]*:)?[A-Za-z0-9._-]+$`)
+
+// subjectNameValidator checks that a string attribute is a subject name.
+func subjectNameValidator() validator.String {
+	return stringvalidator.RegexMatches(subjectNameRegex,
+		"May only contain letters, digits, dots ('.'), underscores ('_') or hyphens ('-'), "+
+			"optionally prefixed with a schema context such as ':.team-a:'")
+}
+
+// regexValidator checks that a string attribute is a valid regular expression.
+type regexValidator struct{}
+
+var _ validator.String = regexValidator{}
+
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid regular expression", err.Error())
+	}
+}
+
+// durationValidator checks that a string attribute is a positive duration such
+// as `30s` or `2m`.
+type durationValidator struct{}
+
+var _ validator.String = durationValidator{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration such as `30s` or `2m`"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", err.Error())
+		return
+	}
+	if duration <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", "The duration must be positive.")
+	}
+}
@@ -0,0 +1,204 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before it expires a token is refreshed, so
+// that it does not expire while a request is in flight.
+const tokenExpiryDelta = 10 * time.Second
+
+// defaultTokenLifetime is how long a token is used when the token endpoint
+// does not say when it expires.
+const defaultTokenLifetime = 5 * time.Minute
+
+// TokenSource supplies the bearer token sent with registry requests.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a TokenSource that always returns the same token.
+type StaticToken string
+
+// Token implements TokenSource.
+func (t StaticToken) Token(_ context.Context) (string, error) {
+	return string(t), nil
+}
+
+// OAuthConfig configures the OAuth 2.0 client credentials grant.
+type OAuthConfig struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	Audience     string
+}
+
+// tokenInvalidator is implemented by token sources whose cached token can be
+// dropped when the registry rejects it, e.g. because it was revoked.
+type tokenInvalidator interface {
+	invalidate(token string)
+}
+
+// oauthTokenSource fetches tokens with the client credentials grant and
+// caches them until shortly before they expire, or until they are rejected.
+type oauthTokenSource struct {
+	config     OAuthConfig
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewOAuthTokenSource returns a TokenSource that fetches tokens from the
+// token endpoint in config using httpClient, refreshing them when they are
+// about to expire or when the registry rejects them.
+func NewOAuthTokenSource(config OAuthConfig, httpClient *http.Client) TokenSource {
+	return &oauthTokenSource{config: config, httpClient: httpClient}
+}
+
+// tokenResponse is the response body of a token endpoint.
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Token implements TokenSource.
+func (s *oauthTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Until(s.expires) > tokenExpiryDelta {
+		return s.token, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+	if s.config.Audience != "" {
+		form.Set("audience", s.config.Audience)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.config.ClientID), url.QueryEscape(s.config.ClientSecret))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not fetch OAuth token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("could not fetch OAuth token: %w", err)
+	}
+
+	var token tokenResponse
+	jsonErr := json.Unmarshal(body, &token)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		if jsonErr == nil && token.Error != "" {
+			return "", fmt.Errorf("could not fetch OAuth token: %s: %s %s", resp.Status, token.Error,
+				token.ErrorDescription)
+		}
+		return "", fmt.Errorf("could not fetch OAuth token: %s", resp.Status)
+	}
+	if jsonErr != nil {
+		return "", fmt.Errorf("could not parse OAuth token response: %w", jsonErr)
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("the OAuth token response does not contain an access token")
+	}
+
+	lifetime := defaultTokenLifetime
+	if token.ExpiresIn > 0 {
+		lifetime = time.Duration(token.ExpiresIn) * time.Second
+	}
+	s.token = token.AccessToken
+	s.expires = time.Now().Add(lifetime)
+	return s.token, nil
+}
+
+// invalidate implements tokenInvalidator. Tokens other than the cached one
+// have already been replaced and are ignored.
+func (s *oauthTokenSource) invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// BearerTransport is an http.RoundTripper that authenticates requests with a
+// bearer token from Source before passing them to Base. A request rejected
+// with 401 Unauthorized is sent once more with a fresh token when Source can
+// drop the rejected one.
+type BearerTransport struct {
+	Base   http.RoundTripper
+	Source TokenSource
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *BearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Source.Token(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	resp, err := t.send(req, req.Body, token)
+	invalidator, ok := t.Source.(tokenInvalidator)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !ok || (req.Body != nil && req.GetBody == nil) {
+		return resp, err
+	}
+
+	// The token may have been revoked or have expired early
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	invalidator.invalidate(token)
+	if token, err = t.Source.Token(req.Context()); err != nil {
+		return nil, err
+	}
+	var body io.ReadCloser
+	if req.Body != nil {
+		if body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return t.send(req, body, token)
+}
+
+// send passes a copy of req with body and token to Base. A RoundTripper must
+// not modify the request it was given.
+func (t *BearerTransport) send(req *http.Request, body io.ReadCloser, token string) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Body = body
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/riferrei/srclient"
+)
+
+// testTokenServer starts a fake OAuth token endpoint that issues tokens
+// expiring after expiresIn seconds and counts the tokens it issued.
+func testTokenServer(t *testing.T, expiresIn int64) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "client_credentials" ||
+			clientID != "terraform" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		}
+		if r.Form.Get("scope") != "registry:read registry:write" || r.Form.Get("audience") != "schema-registry" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_scope"})
+			return
+		}
+
+		n := issued.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("token-%d", n),
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &issued
+}
+
+// testRegistryServer starts a server that records the Authorization header
+// of the last request.
+func testRegistryServer(t *testing.T) (*httptest.Server, *atomic.Value) {
+	t.Helper()
+
+	var authorization atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write([]byte(`["orders-value"]`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &authorization
+}
+
+func testOAuthConfig(tokenURL string) OAuthConfig {
+	return OAuthConfig{
+		TokenURL:     tokenURL,
+		ClientID:     "terraform",
+		ClientSecret: "secret",
+		Scopes:       []string{"registry:read", "registry:write"},
+		Audience:     "schema-registry",
+	}
+}
+
+func TestBearerTransport_staticToken(t *testing.T) {
+	registry, authorization := testRegistryServer(t)
+
+	client := NewClient(registry.URL, &http.Client{
+		Transport: &BearerTransport{Source: StaticToken("static-token")},
+	})
+	if _, err := client.ListSubjects(context.Background(), "", false); err != nil {
+		t.Fatalf("ListSubjects() error = %v", err)
+	}
+
+	if got := authorization.Load(); got != "Bearer static-token" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer static-token")
+	}
+}
+
+func TestBearerTransport_oauth(t *testing.T) {
+	tests := []struct {
+		name       string
+		expiresIn  int64
+		wantIssued int32
+		wantToken  string
+	}{
+		{name: "cached token", expiresIn: 3600, wantIssued: 1, wantToken: "Bearer token-1"},
+		{name: "refreshed token", expiresIn: 1, wantIssued: 2, wantToken: "Bearer token-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, issued := testTokenServer(t, tt.expiresIn)
+			registry, authorization := testRegistryServer(t)
+
+			source := NewOAuthTokenSource(testOAuthConfig(tokens.URL), tokens.Client())
+			client := NewClient(registry.URL, &http.Client{Transport: &BearerTransport{Source: source}})
+			for range 2 {
+				if _, err := client.ListSubjects(context.Background(), "", false); err != nil {
+					t.Fatalf("ListSubjects() error = %v", err)
+				}
+			}
+
+			if got := issued.Load(); got != tt.wantIssued {
+				t.Errorf("issued %d tokens, want %d", got, tt.wantIssued)
+			}
+			if got := authorization.Load(); got != tt.wantToken {
+				t.Errorf("Authorization = %q, want %q", got, tt.wantToken)
+			}
+		})
+	}
+}
+
+func TestBearerTransport_oauthError(t *testing.T) {
+	tokens, _ := testTokenServer(t, 3600)
+	registry, _ := testRegistryServer(t)
+
+	config := testOAuthConfig(tokens.URL)
+	config.ClientSecret = "wrong"
+	source := NewOAuthTokenSource(config, tokens.Client())
+	client := NewClient(registry.URL, &http.Client{Transport: &BearerTransport{Source: source}})
+
+	if _, err := client.ListSubjects(context.Background(), "", false); err == nil {
+		t.Fatal("ListSubjects() error = nil, want the token endpoint error")
+	}
+}
+
+func TestBearerTransport_oauthWithoutExpiry(t *testing.T) {
+	tokens, issued := testTokenServer(t, 0)
+	registry, _ := testRegistryServer(t)
+
+	source := NewOAuthTokenSource(testOAuthConfig(tokens.URL), tokens.Client())
+	client := NewClient(registry.URL, &http.Client{Transport: &BearerTransport{Source: source}})
+	for range 2 {
+		if _, err := client.ListSubjects(context.Background(), "", false); err != nil {
+			t.Fatalf("ListSubjects() error = %v", err)
+		}
+	}
+
+	if got := issued.Load(); got != 1 {
+		t.Errorf("issued %d tokens, want 1", got)
+	}
+	expires := source.(*oauthTokenSource).expires
+	if until := time.Until(expires); until <= 0 || until > defaultTokenLifetime {
+		t.Errorf("token expires in %s, want at most %s", until, defaultTokenLifetime)
+	}
+}
+
+func TestBearerTransport_oauthRejected(t *testing.T) {
+	tokens, issued := testTokenServer(t, 3600)
+
+	// The registry rejects the first token, as it would a revoked one
+	var bodies []string
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error_code":401,"message":"Unauthorized"}`))
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write([]byte(`{"is_compatible":true}`))
+	}))
+	t.Cleanup(registry.Close)
+
+	source := NewOAuthTokenSource(testOAuthConfig(tokens.URL), tokens.Client())
+	client := NewClient(registry.URL, &http.Client{Transport: &BearerTransport{Source: source}})
+	req := NewSchemaRequest(`"string"`, srclient.Avro, nil)
+	if _, err := client.CheckCompatibility(context.Background(), "orders-value", "latest", req, false); err != nil {
+		t.Fatalf("CheckCompatibility() error = %v", err)
+	}
+
+	if got := issued.Load(); got != 2 {
+		t.Errorf("issued %d tokens, want 2", got)
+	}
+	if len(bodies) != 2 || bodies[0] == "" || bodies[1] != bodies[0] {
+		t.Errorf("request bodies = %q, want the same body sent twice", bodies)
+	}
+}
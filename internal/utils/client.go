@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/riferrei/srclient"
+)
+
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+// Client is the Schema Registry client shared by resources and data sources.
+// It talks to the REST API directly, reusing srclient only for its reference
+// and schema type definitions.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	username   string
+	password   string
+	// readBackDelays are the delays between attempts to read back a schema
+	// that was just registered.
+	readBackDelays []time.Duration
+}
+
+// NewClient creates a Client for the registry at baseURL.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// SetCredentials sets the basic authentication credentials for all requests.
+func (c *Client) SetCredentials(username, password string) {
+	c.username = username
+	c.password = password
+}
+
+// SetRetryDelays sets the delays between attempts to read back a schema that
+// was just registered, which a registry instance may not have seen yet when
+// it was registered through another instance.
+func (c *Client) SetRetryDelays(delays []time.Duration) {
+	c.readBackDelays = slices.Clone(delays)
+}
+
+// errorResponse is the error body returned by the Schema Registry API.
+type errorResponse struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// do sends a request to the registry and decodes the JSON response into out,
+// which may be nil. Error responses are returned as *RegistryError.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	uri := c.baseURL + path
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, uri, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", contentType)
+	if c.username != "" && c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		regErr := &RegistryError{StatusCode: resp.StatusCode, Message: resp.Status}
+		var errResp errorResponse
+		if json.Unmarshal(respBody, &errResp) == nil && (errResp.ErrorCode != 0 || errResp.Message != "") {
+			regErr.ErrorCode = errResp.ErrorCode
+			regErr.Message = errResp.Message
+		}
+		return regErr
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// subjectPath builds a request path with an escaped subject.
+func subjectPath(format, subject string, args ...any) string {
+	return fmt.Sprintf(format, append([]any{url.PathEscape(subject)}, args...)...)
+}
+
+// SchemaRequest is the request body used to register, look up and check
+// schemas. ID and Version are only accepted by registries in IMPORT mode.
+// Registries without data contract support ignore Metadata and RuleSet.
+type SchemaRequest struct {
+	Schema     string               `json:"schema"`
+	SchemaType string               `json:"schemaType,omitempty"`
+	References []srclient.Reference `json:"references,omitempty"`
+	Metadata   *Metadata            `json:"metadata,omitempty"`
+	RuleSet    *RuleSet             `json:"ruleSet,omitempty"`
+	ID         int                  `json:"id,omitempty"`
+	Version    int                  `json:"version,omitempty"`
+}
+
+// NewSchemaRequest builds a SchemaRequest. The schema type is omitted for AVRO
+// for compatibility with registries that predate schema types.
+func NewSchemaRequest(schema string, schemaType srclient.SchemaType, references []srclient.Reference) SchemaRequest {
+	return SchemaRequest{
+		Schema:     schema,
+		SchemaType: schemaType.String(),
+		References: references,
+	}
+}
+
+// registerResponse is the response body of a schema registration.
+type registerResponse struct {
+	ID int `json:"id"`
+}
+
+// RegisterSchema registers a schema under a subject and returns its ID. The
+// registry stores the normalized schema when normalize is set:
+//
+//	POST /subjects/{subject}/versions?normalize={normalize}
+func (c *Client) RegisterSchema(ctx context.Context, subject string, req SchemaRequest, normalize bool) (int, error) {
+	var query url.Values
+	if normalize {
+		query = url.Values{"normalize": {"true"}}
+	}
+
+	var resp registerResponse
+	if err := c.do(ctx, http.MethodPost, subjectPath("/subjects/%s/versions", subject), query, req, &resp); err != nil {
+		return 0, err
+	}
+
+	return resp.ID, nil
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// CompatibilityResult is the outcome of a compatibility check.
+type CompatibilityResult struct {
+	IsCompatible bool     `json:"is_compatible"`
+	Messages     []string `json:"messages"`
+}
+
+// CheckCompatibility tests a schema against a version of the subject without
+// registering it:
+//
+//	POST /compatibility/subjects/{subject}/versions/{version}?verbose=true&normalize={normalize}
+//
+// An empty version checks against all versions the subject's compatibility
+// level applies to. The registry's incompatibility reasons are returned in
+// the result messages.
+func (c *Client) CheckCompatibility(ctx context.Context, subject, version string, req SchemaRequest,
+	normalize bool) (*CompatibilityResult, error) {
+	path := subjectPath("/compatibility/subjects/%s/versions", subject)
+	if version != "" {
+		path += "/" + url.PathEscape(version)
+	}
+
+	query := url.Values{"verbose": {"true"}}
+	if normalize {
+		query.Set("normalize", "true")
+	}
+
+	var result CompatibilityResult
+	err := c.do(ctx, http.MethodPost, path, query, req, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
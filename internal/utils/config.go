@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/riferrei/srclient"
+)
+
+// Config is the configuration of a subject, or the global configuration of
+// the registry.
+type Config struct {
+	CompatibilityLevel string    `json:"compatibilityLevel,omitempty"`
+	Normalize          *bool     `json:"normalize,omitempty"`
+	Alias              string    `json:"alias,omitempty"`
+	CompatibilityGroup string    `json:"compatibilityGroup,omitempty"`
+	DefaultMetadata    *Metadata `json:"defaultMetadata,omitempty"`
+	OverrideMetadata   *Metadata `json:"overrideMetadata,omitempty"`
+	DefaultRuleSet     *RuleSet  `json:"defaultRuleSet,omitempty"`
+	OverrideRuleSet    *RuleSet  `json:"overrideRuleSet,omitempty"`
+}
+
+// configUpdateRequest is the body of PUT /config, which names the
+// compatibility level differently from the GET response.
+type configUpdateRequest struct {
+	Compatibility      string    `json:"compatibility,omitempty"`
+	Normalize          *bool     `json:"normalize,omitempty"`
+	Alias              string    `json:"alias,omitempty"`
+	CompatibilityGroup string    `json:"compatibilityGroup,omitempty"`
+	DefaultMetadata    *Metadata `json:"defaultMetadata,omitempty"`
+	OverrideMetadata   *Metadata `json:"overrideMetadata,omitempty"`
+	DefaultRuleSet     *RuleSet  `json:"defaultRuleSet,omitempty"`
+	OverrideRuleSet    *RuleSet  `json:"overrideRuleSet,omitempty"`
+}
+
+// configPath returns the config endpoint for a subject, or the global config
+// endpoint when subject is empty.
+func configPath(subject string) string {
+	if subject == "" {
+		return "/config"
+	}
+	return subjectPath("/config/%s", subject)
+}
+
+// GetConfig returns the configuration of a subject, or the global
+// configuration when subject is empty:
+//
+//	GET /config/{subject}?defaultToGlobal={defaultToGlobal}
+func (c *Client) GetConfig(ctx context.Context, subject string, defaultToGlobal bool) (*Config, error) {
+	var query url.Values
+	if subject != "" {
+		query = url.Values{"defaultToGlobal": {strconv.FormatBool(defaultToGlobal)}}
+	}
+
+	var config Config
+	if err := c.do(ctx, http.MethodGet, configPath(subject), query, nil, &config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// GetCompatibilityLevel returns the compatibility level of a subject, falling
+// back to the global level, and to BACKWARD when neither is set.
+func (c *Client) GetCompatibilityLevel(ctx context.Context, subject string) (string, error) {
+	config, err := c.GetConfig(ctx, subject, true)
+	if err != nil {
+		return "", err
+	}
+	if config.CompatibilityLevel == "" {
+		return FromCompatibilityLevelType(srclient.Backward), nil
+	}
+	return config.CompatibilityLevel, nil
+}
+
+// UpdateConfig sets the configuration of a subject, or the global
+// configuration when subject is empty. Fields left empty are not changed:
+//
+//	PUT /config/{subject}
+func (c *Client) UpdateConfig(ctx context.Context, subject string, config *Config) error {
+	req := configUpdateRequest{
+		Compatibility:      config.CompatibilityLevel,
+		Normalize:          config.Normalize,
+		Alias:              config.Alias,
+		CompatibilityGroup: config.CompatibilityGroup,
+		DefaultMetadata:    config.DefaultMetadata,
+		OverrideMetadata:   config.OverrideMetadata,
+		DefaultRuleSet:     config.DefaultRuleSet,
+		OverrideRuleSet:    config.OverrideRuleSet,
+	}
+
+	return c.do(ctx, http.MethodPut, configPath(subject), nil, req, nil)
+}
+
+// DeleteConfig removes the configuration of a subject so that it falls back to
+// the global configuration, or resets the global configuration to the
+// registry defaults when subject is empty:
+//
+//	DELETE /config/{subject}
+func (c *Client) DeleteConfig(ctx context.Context, subject string) error {
+	return c.do(ctx, http.MethodDelete, configPath(subject), nil, nil, nil)
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/riferrei/srclient"
+)
+
+// DefaultContext is the name of the default schema context.
+const DefaultContext = "."
+
+// contextPrefix returns the subject prefix of a schema context, or an empty
+// string for the default context. Context names may be given with or
+// without their leading dot.
+func contextPrefix(schemaContext string) string {
+	name := strings.TrimPrefix(schemaContext, ".")
+	if name == "" {
+		return ""
+	}
+	return ":." + name + ":"
+}
+
+// QualifySubject returns subject qualified with a schema context, e.g.
+// `:.team-a:orders-value`. Subjects that are already qualified and subjects
+// in the default context are returned unchanged.
+func QualifySubject(schemaContext, subject string) string {
+	if strings.HasPrefix(subject, ":.") {
+		return subject
+	}
+	return contextPrefix(schemaContext) + subject
+}
+
+// UnqualifySubject strips the qualifier of schemaContext from subject.
+// Subjects in other contexts are returned unchanged.
+func UnqualifySubject(schemaContext, subject string) string {
+	prefix := contextPrefix(schemaContext)
+	if prefix == "" {
+		return subject
+	}
+	return strings.TrimPrefix(subject, prefix)
+}
+
+// SplitSubject splits a subject qualified with a schema context, e.g.
+// `:.team-a:orders-value`, into the context and the unqualified subject.
+// Unqualified subjects are returned with an empty context.
+func SplitSubject(subject string) (string, string) {
+	if !strings.HasPrefix(subject, ":.") {
+		return "", subject
+	}
+	schemaContext, name, ok := strings.Cut(subject[1:], ":")
+	if !ok {
+		return "", subject
+	}
+	return schemaContext, name
+}
+
+// contextQuery returns the query that scopes a schema ID lookup to the schema
+// context of subject, or nil for the default context.
+func contextQuery(subject string) url.Values {
+	schemaContext, _ := SplitSubject(subject)
+	if prefix := contextPrefix(schemaContext); prefix != "" {
+		return url.Values{"subject": {prefix}}
+	}
+	return nil
+}
+
+// QualifyReferences qualifies the subjects of references with a schema context.
+func QualifyReferences(schemaContext string, references []srclient.Reference) []srclient.Reference {
+	return mapReferenceSubjects(references, func(subject string) string {
+		return QualifySubject(schemaContext, subject)
+	})
+}
+
+// UnqualifyReferences strips the qualifier of schemaContext from the subjects
+// of references.
+func UnqualifyReferences(schemaContext string, references []srclient.Reference) []srclient.Reference {
+	return mapReferenceSubjects(references, func(subject string) string {
+		return UnqualifySubject(schemaContext, subject)
+	})
+}
+
+func mapReferenceSubjects(references []srclient.Reference, f func(string) string) []srclient.Reference {
+	if references == nil {
+		return nil
+	}
+
+	out := make([]srclient.Reference, len(references))
+	for i, reference := range references {
+		reference.Subject = f(reference.Subject)
+		out[i] = reference
+	}
+	return out
+}
+
+// GetContexts returns the names of the schema contexts in the registry:
+//
+//	GET /contexts
+func (c *Client) GetContexts(ctx context.Context) ([]string, error) {
+	var contexts []string
+	if err := c.do(ctx, http.MethodGet, "/contexts", nil, nil, &contexts); err != nil {
+		return nil, err
+	}
+
+	return contexts, nil
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/riferrei/srclient"
+)
+
+func TestQualifySubject(t *testing.T) {
+	tests := []struct {
+		name          string
+		schemaContext string
+		subject       string
+		want          string
+	}{
+		{name: "no context", schemaContext: "", subject: "orders-value", want: "orders-value"},
+		{name: "default context", schemaContext: ".", subject: "orders-value", want: "orders-value"},
+		{name: "context with dot", schemaContext: ".team-a", subject: "orders-value", want: ":.team-a:orders-value"},
+		{name: "context without dot", schemaContext: "team-a", subject: "orders-value", want: ":.team-a:orders-value"},
+		{name: "already qualified", schemaContext: ".team-a", subject: ":.team-b:orders-value", want: ":.team-b:orders-value"},
+		{name: "context default subject", schemaContext: ".team-a", subject: "", want: ":.team-a:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := QualifySubject(tt.schemaContext, tt.subject); got != tt.want {
+				t.Errorf("QualifySubject(%q, %q) = %q, want %q", tt.schemaContext, tt.subject, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnqualifySubject(t *testing.T) {
+	tests := []struct {
+		name          string
+		schemaContext string
+		subject       string
+		want          string
+	}{
+		{name: "no context", schemaContext: "", subject: ":.team-a:orders-value", want: ":.team-a:orders-value"},
+		{name: "same context", schemaContext: ".team-a", subject: ":.team-a:orders-value", want: "orders-value"},
+		{name: "other context", schemaContext: ".team-a", subject: ":.team-b:orders-value", want: ":.team-b:orders-value"},
+		{name: "unqualified", schemaContext: "team-a", subject: "orders-value", want: "orders-value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnqualifySubject(tt.schemaContext, tt.subject); got != tt.want {
+				t.Errorf("UnqualifySubject(%q, %q) = %q, want %q", tt.schemaContext, tt.subject, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitSubject(t *testing.T) {
+	tests := []struct {
+		subject     string
+		wantContext string
+		wantSubject string
+	}{
+		{subject: "orders-value", wantContext: "", wantSubject: "orders-value"},
+		{subject: ":.team-a:orders-value", wantContext: ".team-a", wantSubject: "orders-value"},
+		{subject: ":.team-a:", wantContext: ".team-a", wantSubject: ""},
+		{subject: ":.team-a", wantContext: "", wantSubject: ":.team-a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			schemaContext, subject := SplitSubject(tt.subject)
+			if schemaContext != tt.wantContext || subject != tt.wantSubject {
+				t.Errorf("SplitSubject(%q) = %q, %q, want %q, %q", tt.subject, schemaContext, subject,
+					tt.wantContext, tt.wantSubject)
+			}
+		})
+	}
+}
+
+func TestQualifyReferencesRoundTrip(t *testing.T) {
+	refs := []srclient.Reference{
+		{Name: "Address", Subject: "address-value", Version: 1},
+		{Name: "Shared", Subject: ":.shared:common-value", Version: 2},
+	}
+
+	qualified := QualifyReferences(".team-a", refs)
+	if qualified[0].Subject != ":.team-a:address-value" || qualified[1].Subject != ":.shared:common-value" {
+		t.Fatalf("unexpected qualified references: %+v", qualified)
+	}
+	if refs[0].Subject != "address-value" {
+		t.Fatalf("QualifyReferences modified its input: %+v", refs)
+	}
+
+	unqualified := UnqualifyReferences(".team-a", qualified)
+	if unqualified[0] != refs[0] || unqualified[1] != refs[1] {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", unqualified, refs)
+	}
+}
@@ -16,14 +16,15 @@ type refItem struct {
 	Version int64  `tfsdk:"version"`
 }
 
+// ReferenceAttrTypes are the Terraform attribute types of a schema reference.
+var ReferenceAttrTypes = map[string]attr.Type{
+	"name":    types.StringType,
+	"subject": types.StringType,
+	"version": types.Int64Type,
+}
+
 func FromRegistryReferences(references []srclient.Reference) types.List {
-	referenceType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"name":    types.StringType,
-			"subject": types.StringType,
-			"version": types.Int64Type,
-		},
-	}
+	referenceType := types.ObjectType{AttrTypes: ReferenceAttrTypes}
 
 	if len(references) == 0 {
 		return types.ListNull(referenceType)
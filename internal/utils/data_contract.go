@@ -0,0 +1,373 @@
+package utils
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Metadata is the data contract metadata attached to a schema or configured as
+// a subject default.
+type Metadata struct {
+	Tags       map[string][]string `json:"tags,omitempty"`
+	Properties map[string]string   `json:"properties,omitempty"`
+	Sensitive  []string            `json:"sensitive,omitempty"`
+}
+
+// Rule is a single data contract rule.
+type Rule struct {
+	Name      string            `json:"name"`
+	Doc       string            `json:"doc,omitempty"`
+	Kind      string            `json:"kind"`
+	Mode      string            `json:"mode"`
+	Type      string            `json:"type"`
+	Tags      []string          `json:"tags,omitempty"`
+	Params    map[string]string `json:"params,omitempty"`
+	Expr      string            `json:"expr,omitempty"`
+	OnSuccess string            `json:"onSuccess,omitempty"`
+	OnFailure string            `json:"onFailure,omitempty"`
+	Disabled  bool              `json:"disabled,omitempty"`
+}
+
+// RuleSet groups the migration and domain rules of a data contract.
+type RuleSet struct {
+	MigrationRules []Rule `json:"migrationRules,omitempty"`
+	DomainRules    []Rule `json:"domainRules,omitempty"`
+}
+
+// MetadataAttrTypes are the Terraform attribute types of a metadata object.
+var MetadataAttrTypes = map[string]attr.Type{
+	"tags":       types.MapType{ElemType: types.SetType{ElemType: types.StringType}},
+	"properties": types.MapType{ElemType: types.StringType},
+	"sensitive":  types.SetType{ElemType: types.StringType},
+}
+
+// RuleAttrTypes are the Terraform attribute types of a rule object.
+var RuleAttrTypes = map[string]attr.Type{
+	"name":       types.StringType,
+	"doc":        types.StringType,
+	"kind":       types.StringType,
+	"mode":       types.StringType,
+	"type":       types.StringType,
+	"tags":       types.SetType{ElemType: types.StringType},
+	"params":     types.MapType{ElemType: types.StringType},
+	"expr":       types.StringType,
+	"on_success": types.StringType,
+	"on_failure": types.StringType,
+	"disabled":   types.BoolType,
+}
+
+// RuleSetAttrTypes are the Terraform attribute types of a rule set object.
+var RuleSetAttrTypes = map[string]attr.Type{
+	"migration_rules": types.ListType{ElemType: types.ObjectType{AttrTypes: RuleAttrTypes}},
+	"domain_rules":    types.ListType{ElemType: types.ObjectType{AttrTypes: RuleAttrTypes}},
+}
+
+type metadataItem struct {
+	Tags       types.Map `tfsdk:"tags"`
+	Properties types.Map `tfsdk:"properties"`
+	Sensitive  types.Set `tfsdk:"sensitive"`
+}
+
+type ruleItem struct {
+	Name      types.String `tfsdk:"name"`
+	Doc       types.String `tfsdk:"doc"`
+	Kind      types.String `tfsdk:"kind"`
+	Mode      types.String `tfsdk:"mode"`
+	Type      types.String `tfsdk:"type"`
+	Tags      types.Set    `tfsdk:"tags"`
+	Params    types.Map    `tfsdk:"params"`
+	Expr      types.String `tfsdk:"expr"`
+	OnSuccess types.String `tfsdk:"on_success"`
+	OnFailure types.String `tfsdk:"on_failure"`
+	Disabled  types.Bool   `tfsdk:"disabled"`
+}
+
+type ruleSetItem struct {
+	MigrationRules types.List `tfsdk:"migration_rules"`
+	DomainRules    types.List `tfsdk:"domain_rules"`
+}
+
+// ToRegistryMetadata turns a Terraform metadata object into registry metadata.
+func ToRegistryMetadata(ctx context.Context, in types.Object) (*Metadata, diag.Diagnostics) {
+	if in.IsNull() || in.IsUnknown() {
+		return nil, nil
+	}
+
+	var item metadataItem
+	diags := in.As(ctx, &item, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	metadata := &Metadata{}
+	diags.Append(item.Tags.ElementsAs(ctx, &metadata.Tags, false)...)
+	diags.Append(item.Properties.ElementsAs(ctx, &metadata.Properties, false)...)
+	diags.Append(item.Sensitive.ElementsAs(ctx, &metadata.Sensitive, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return metadata, diags
+}
+
+// FromRegistryMetadata turns registry metadata into a Terraform metadata
+// object. Empty metadata becomes an object with null attributes, so that
+// only missing metadata is null.
+func FromRegistryMetadata(ctx context.Context, metadata *Metadata) (types.Object, diag.Diagnostics) {
+	if metadata == nil {
+		return types.ObjectNull(MetadataAttrTypes), nil
+	}
+
+	var diags, d diag.Diagnostics
+	item := metadataItem{
+		Tags:       types.MapNull(types.SetType{ElemType: types.StringType}),
+		Properties: types.MapNull(types.StringType),
+		Sensitive:  types.SetNull(types.StringType),
+	}
+	if len(metadata.Tags) > 0 {
+		item.Tags, d = types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, metadata.Tags)
+		diags.Append(d...)
+	}
+	if len(metadata.Properties) > 0 {
+		item.Properties, d = types.MapValueFrom(ctx, types.StringType, metadata.Properties)
+		diags.Append(d...)
+	}
+	if len(metadata.Sensitive) > 0 {
+		item.Sensitive, d = types.SetValueFrom(ctx, types.StringType, metadata.Sensitive)
+		diags.Append(d...)
+	}
+	if diags.HasError() {
+		return types.ObjectNull(MetadataAttrTypes), diags
+	}
+
+	out, d := types.ObjectValueFrom(ctx, MetadataAttrTypes, item)
+	diags.Append(d...)
+	return out, diags
+}
+
+// ToRegistryRuleSet turns a Terraform rule set object into a registry rule set.
+func ToRegistryRuleSet(ctx context.Context, in types.Object) (*RuleSet, diag.Diagnostics) {
+	if in.IsNull() || in.IsUnknown() {
+		return nil, nil
+	}
+
+	var item ruleSetItem
+	diags := in.As(ctx, &item, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var d diag.Diagnostics
+	ruleSet := &RuleSet{}
+	ruleSet.MigrationRules, d = toRegistryRules(ctx, item.MigrationRules)
+	diags.Append(d...)
+	ruleSet.DomainRules, d = toRegistryRules(ctx, item.DomainRules)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return ruleSet, diags
+}
+
+// FromRegistryRuleSet turns a registry rule set into a Terraform rule set
+// object. An empty rule set becomes an object with null attributes, so that
+// only a missing rule set is null.
+func FromRegistryRuleSet(ctx context.Context, ruleSet *RuleSet) (types.Object, diag.Diagnostics) {
+	if ruleSet == nil {
+		return types.ObjectNull(RuleSetAttrTypes), nil
+	}
+
+	var diags, d diag.Diagnostics
+	item := ruleSetItem{}
+	item.MigrationRules, d = fromRegistryRules(ctx, ruleSet.MigrationRules)
+	diags.Append(d...)
+	item.DomainRules, d = fromRegistryRules(ctx, ruleSet.DomainRules)
+	diags.Append(d...)
+	if diags.HasError() {
+		return types.ObjectNull(RuleSetAttrTypes), diags
+	}
+
+	out, d := types.ObjectValueFrom(ctx, RuleSetAttrTypes, item)
+	diags.Append(d...)
+	return out, diags
+}
+
+func toRegistryRules(ctx context.Context, in types.List) ([]Rule, diag.Diagnostics) {
+	if in.IsNull() || in.IsUnknown() {
+		return nil, nil
+	}
+
+	var items []ruleItem
+	diags := in.ElementsAs(ctx, &items, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	rules := make([]Rule, 0, len(items))
+	for _, it := range items {
+		rule := Rule{
+			Name:      it.Name.ValueString(),
+			Doc:       it.Doc.ValueString(),
+			Kind:      it.Kind.ValueString(),
+			Mode:      it.Mode.ValueString(),
+			Type:      it.Type.ValueString(),
+			Expr:      it.Expr.ValueString(),
+			OnSuccess: it.OnSuccess.ValueString(),
+			OnFailure: it.OnFailure.ValueString(),
+			Disabled:  it.Disabled.ValueBool(),
+		}
+		diags.Append(it.Tags.ElementsAs(ctx, &rule.Tags, false)...)
+		diags.Append(it.Params.ElementsAs(ctx, &rule.Params, false)...)
+		rules = append(rules, rule)
+	}
+
+	return rules, diags
+}
+
+func fromRegistryRules(ctx context.Context, rules []Rule) (types.List, diag.Diagnostics) {
+	ruleType := types.ObjectType{AttrTypes: RuleAttrTypes}
+	if len(rules) == 0 {
+		return types.ListNull(ruleType), nil
+	}
+
+	var diags, d diag.Diagnostics
+	items := make([]ruleItem, 0, len(rules))
+	for _, rule := range rules {
+		item := ruleItem{
+			Name:      types.StringValue(rule.Name),
+			Doc:       OptionalString(rule.Doc),
+			Kind:      types.StringValue(rule.Kind),
+			Mode:      types.StringValue(rule.Mode),
+			Type:      types.StringValue(rule.Type),
+			Tags:      types.SetNull(types.StringType),
+			Params:    types.MapNull(types.StringType),
+			Expr:      OptionalString(rule.Expr),
+			OnSuccess: OptionalString(rule.OnSuccess),
+			OnFailure: OptionalString(rule.OnFailure),
+			Disabled:  types.BoolValue(rule.Disabled),
+		}
+		if len(rule.Tags) > 0 {
+			item.Tags, d = types.SetValueFrom(ctx, types.StringType, rule.Tags)
+			diags.Append(d...)
+		}
+		if len(rule.Params) > 0 {
+			item.Params, d = types.MapValueFrom(ctx, types.StringType, rule.Params)
+			diags.Append(d...)
+		}
+		items = append(items, item)
+	}
+	if diags.HasError() {
+		return types.ListNull(ruleType), diags
+	}
+
+	out, d := types.ListValueFrom(ctx, ruleType, items)
+	diags.Append(d...)
+	return out, diags
+}
+
+// generatedPropertyPrefix prefixes the metadata properties the registry adds
+// itself, such as `confluent:version`.
+const generatedPropertyPrefix = "confluent:"
+
+// WithoutGeneratedProperties returns a copy of metadata without the
+// properties the registry adds on registration, so that they do not show up
+// as drift from the configured metadata. It returns nil when the metadata
+// holds nothing but generated properties.
+func WithoutGeneratedProperties(metadata *Metadata) *Metadata {
+	if metadata == nil {
+		return nil
+	}
+	generatedOnly := len(metadata.Tags) == 0 && len(metadata.Sensitive) == 0 && len(metadata.Properties) > 0
+
+	out := *metadata
+	out.Properties = make(map[string]string, len(metadata.Properties))
+	for k, v := range metadata.Properties {
+		if !strings.HasPrefix(k, generatedPropertyPrefix) {
+			out.Properties[k] = v
+			generatedOnly = false
+		}
+	}
+	if generatedOnly {
+		return nil
+	}
+	return &out
+}
+
+// MetadataEqual reports whether two metadata objects are equal, ignoring the
+// order of tags and sensitive properties and the properties generated by the
+// registry.
+func MetadataEqual(a, b *Metadata) bool {
+	return reflect.DeepEqual(normalizeMetadata(a), normalizeMetadata(b))
+}
+
+// RuleSetEqual reports whether two rule sets hold the same rules in the same
+// order, ignoring the order of rule tags.
+func RuleSetEqual(a, b *RuleSet) bool {
+	return reflect.DeepEqual(normalizeRuleSet(a), normalizeRuleSet(b))
+}
+
+func normalizeMetadata(metadata *Metadata) Metadata {
+	var out Metadata
+	metadata = WithoutGeneratedProperties(metadata)
+	if metadata == nil {
+		return out
+	}
+
+	for k, v := range metadata.Tags {
+		if len(v) == 0 {
+			continue
+		}
+		if out.Tags == nil {
+			out.Tags = map[string][]string{}
+		}
+		out.Tags[k] = slices.Sorted(slices.Values(v))
+	}
+	if len(metadata.Properties) > 0 {
+		out.Properties = metadata.Properties
+	}
+	out.Sensitive = slices.Sorted(slices.Values(metadata.Sensitive))
+	return out
+}
+
+func normalizeRuleSet(ruleSet *RuleSet) RuleSet {
+	var out RuleSet
+	if ruleSet == nil {
+		return out
+	}
+
+	out.MigrationRules = normalizeRules(ruleSet.MigrationRules)
+	out.DomainRules = normalizeRules(ruleSet.DomainRules)
+	return out
+}
+
+func normalizeRules(rules []Rule) []Rule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	out := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		rule.Tags = slices.Sorted(slices.Values(rule.Tags))
+		if len(rule.Params) == 0 {
+			rule.Params = nil
+		}
+		out = append(out, rule)
+	}
+	return out
+}
+
+// OptionalString returns a null string for empty values.
+func OptionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMetadataEqual(t *testing.T) {
+	metadata := &Metadata{
+		Tags:       map[string][]string{"email": {"PII", "EMAIL"}},
+		Properties: map[string]string{"owner": "team-a"},
+		Sensitive:  []string{"b", "a"},
+	}
+
+	tests := []struct {
+		name  string
+		a, b  *Metadata
+		equal bool
+	}{
+		{name: "both nil", a: nil, b: nil, equal: true},
+		{name: "nil and empty", a: nil, b: &Metadata{Properties: map[string]string{}}, equal: true},
+		{name: "same", a: metadata, b: metadata, equal: true},
+		{
+			name: "different order",
+			a:    metadata,
+			b: &Metadata{
+				Tags:       map[string][]string{"email": {"EMAIL", "PII"}},
+				Properties: map[string]string{"owner": "team-a"},
+				Sensitive:  []string{"a", "b"},
+			},
+			equal: true,
+		},
+		{
+			name: "generated property",
+			a:    metadata,
+			b: &Metadata{
+				Tags:       map[string][]string{"email": {"PII", "EMAIL"}},
+				Properties: map[string]string{"owner": "team-a", "confluent:version": "2"},
+				Sensitive:  []string{"b", "a"},
+			},
+			equal: true,
+		},
+		{name: "nil and set", a: nil, b: metadata, equal: false},
+		{
+			name:  "different property",
+			a:     metadata,
+			b:     &Metadata{Properties: map[string]string{"owner": "team-b"}},
+			equal: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MetadataEqual(tt.a, tt.b); got != tt.equal {
+				t.Errorf("MetadataEqual() = %t, want %t", got, tt.equal)
+			}
+		})
+	}
+}
+
+func TestRuleSetEqual(t *testing.T) {
+	rule := Rule{Name: "checkEmail", Kind: "CONDITION", Mode: "WRITE", Type: "CEL", Expr: "size(message.email) > 0"}
+	other := Rule{Name: "upgrade", Kind: "TRANSFORM", Mode: "UPGRADE", Type: "JSONATA", Expr: "$"}
+
+	tests := []struct {
+		name  string
+		a, b  *RuleSet
+		equal bool
+	}{
+		{name: "both nil", a: nil, b: nil, equal: true},
+		{name: "nil and empty", a: nil, b: &RuleSet{DomainRules: []Rule{}}, equal: true},
+		{name: "same", a: &RuleSet{DomainRules: []Rule{rule}}, b: &RuleSet{DomainRules: []Rule{rule}}, equal: true},
+		{
+			name:  "tag order and empty params",
+			a:     &RuleSet{DomainRules: []Rule{{Name: "r", Tags: []string{"PII", "EMAIL"}}}},
+			b:     &RuleSet{DomainRules: []Rule{{Name: "r", Tags: []string{"EMAIL", "PII"}, Params: map[string]string{}}}},
+			equal: true,
+		},
+		{
+			name:  "rule order",
+			a:     &RuleSet{DomainRules: []Rule{rule, other}},
+			b:     &RuleSet{DomainRules: []Rule{other, rule}},
+			equal: false,
+		},
+		{
+			name:  "migration and domain rules",
+			a:     &RuleSet{DomainRules: []Rule{other}},
+			b:     &RuleSet{MigrationRules: []Rule{other}},
+			equal: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RuleSetEqual(tt.a, tt.b); got != tt.equal {
+				t.Errorf("RuleSetEqual() = %t, want %t", got, tt.equal)
+			}
+		})
+	}
+}
+
+func TestFromRegistryMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata *Metadata
+		null     bool
+	}{
+		{name: "nil", metadata: nil, null: true},
+		{name: "empty", metadata: &Metadata{}, null: false},
+		{name: "properties", metadata: &Metadata{Properties: map[string]string{"owner": "team-a"}}, null: false},
+		{
+			name:     "generated properties only",
+			metadata: WithoutGeneratedProperties(&Metadata{Properties: map[string]string{"confluent:version": "1"}}),
+			null:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := FromRegistryMetadata(context.Background(), tt.metadata)
+			if diags.HasError() {
+				t.Fatalf("FromRegistryMetadata() diagnostics = %v", diags)
+			}
+			if got.IsNull() != tt.null {
+				t.Errorf("FromRegistryMetadata() null = %t, want %t", got.IsNull(), tt.null)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/riferrei/srclient"
+)
+
+// Confluent Schema Registry error codes.
+const (
+	errorCodeSubjectNotFound       = 40401
+	errorCodeVersionNotFound       = 40402
+	errorCodeSchemaNotFound        = 40403
+	errorCodeSubjectSoftDeleted    = 40404
+	errorCodeConfigNotFound        = 40408
+	errorCodeModeNotFound          = 40409
+	errorCodeIncompatibleSchema    = 409
+	errorCodeInvalidSchema         = 42201
+	errorCodeInvalidCompatibility  = 42203
+	errorCodeInvalidMode           = 42204
+	errorCodeOperationNotPermitted = 42205
+	errorCodeReferenceExists       = 42206
+	errorCodeUnauthorized          = 401
+	errorCodeForbidden             = 403
+	errorCodeInternalServerError   = 500
+	errorCodeServerErrorDetailsMin = 50000
+)
+
+// Sentinel errors for the registry failures the provider reacts to. Use
+// errors.Is on the result of ClassifyError to test for them.
+var (
+	ErrSubjectNotFound           = errors.New("subject not found")
+	ErrVersionNotFound           = errors.New("version not found")
+	ErrSchemaNotFound            = errors.New("schema not found")
+	ErrSubjectSoftDeleted        = errors.New("subject was soft deleted")
+	ErrConfigNotFound            = errors.New("subject config not found")
+	ErrModeNotFound              = errors.New("subject mode not found")
+	ErrIncompatibleSchema        = errors.New("schema is incompatible")
+	ErrInvalidSchema             = errors.New("invalid schema")
+	ErrInvalidCompatibilityLevel = errors.New("invalid compatibility level")
+	ErrInvalidMode               = errors.New("invalid mode")
+	ErrOperationNotPermitted     = errors.New("operation not permitted")
+	ErrReferenceExists           = errors.New("schema is referenced by other schemas")
+	ErrUnauthorized              = errors.New("unauthorized")
+	ErrForbidden                 = errors.New("forbidden")
+	ErrServerError               = errors.New("schema registry server error")
+)
+
+// RegistryError is an error response returned by the Schema Registry API.
+type RegistryError struct {
+	// StatusCode is the HTTP status code, when known.
+	StatusCode int
+	// ErrorCode is the registry error_code from the response body, when present.
+	ErrorCode int
+	// Message is the registry message from the response body, or the HTTP status.
+	Message string
+	// Messages holds additional details, such as verbose incompatibility reasons.
+	Messages []string
+}
+
+// Error implements error.
+func (e *RegistryError) Error() string {
+	var b strings.Builder
+	if e.ErrorCode != 0 {
+		fmt.Fprintf(&b, "error code %d: ", e.ErrorCode)
+	} else if e.StatusCode != 0 {
+		fmt.Fprintf(&b, "HTTP %d: ", e.StatusCode)
+	}
+	b.WriteString(e.Message)
+	if len(e.Messages) > 0 {
+		b.WriteString(" (" + strings.Join(e.Messages, "; ") + ")")
+	}
+	return b.String()
+}
+
+// Unwrap returns the sentinel error matching the registry error code. A
+// response with only an HTTP status such as 404 or 409, e.g. from a wrong URL
+// or a gateway, is not matched to a registry error except for
+// authentication and server errors.
+func (e *RegistryError) Unwrap() error {
+	switch e.ErrorCode {
+	case errorCodeSubjectNotFound:
+		return ErrSubjectNotFound
+	case errorCodeVersionNotFound:
+		return ErrVersionNotFound
+	case errorCodeSchemaNotFound:
+		return ErrSchemaNotFound
+	case errorCodeSubjectSoftDeleted:
+		return ErrSubjectSoftDeleted
+	case errorCodeConfigNotFound:
+		return ErrConfigNotFound
+	case errorCodeModeNotFound:
+		return ErrModeNotFound
+	case errorCodeIncompatibleSchema:
+		return ErrIncompatibleSchema
+	case errorCodeInvalidSchema:
+		return ErrInvalidSchema
+	case errorCodeInvalidCompatibility:
+		return ErrInvalidCompatibilityLevel
+	case errorCodeInvalidMode:
+		return ErrInvalidMode
+	case errorCodeOperationNotPermitted:
+		return ErrOperationNotPermitted
+	case errorCodeReferenceExists:
+		return ErrReferenceExists
+	}
+
+	// Authentication errors are reported either as the HTTP status or as a
+	// more specific five digit code such as 40101 or 40301
+	switch {
+	case e.ErrorCode == errorCodeUnauthorized || e.ErrorCode/100 == errorCodeUnauthorized ||
+		e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.ErrorCode == errorCodeForbidden || e.ErrorCode/100 == errorCodeForbidden ||
+		e.StatusCode == http.StatusForbidden:
+		return ErrForbidden
+	case e.StatusCode >= http.StatusInternalServerError || e.ErrorCode >= errorCodeServerErrorDetailsMin ||
+		e.ErrorCode/100 == errorCodeInternalServerError/100:
+		return ErrServerError
+	}
+
+	return nil
+}
+
+// ClassifyError converts errors returned by srclient into a *RegistryError so
+// they can be matched against the sentinel errors. Errors that did not come
+// from a registry response, such as connection failures, are returned as is.
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var regErr *RegistryError
+	if errors.As(err, &regErr) {
+		return err
+	}
+
+	var srErr srclient.Error
+	if errors.As(err, &srErr) {
+		return &RegistryError{ErrorCode: srErr.Code, Message: srErr.Message}
+	}
+
+	if errors.Is(err, srclient.ErrSemanticSchemaNotFound) {
+		return &RegistryError{ErrorCode: errorCodeSchemaNotFound, Message: err.Error()}
+	}
+
+	// Registries that answer without a JSON error body only expose the HTTP
+	// status, which srclient reports as e.g. "404 Not Found"
+	if status, text, ok := strings.Cut(err.Error(), " "); ok && len(status) == 3 {
+		if code, convErr := strconv.Atoi(status); convErr == nil && code >= 400 {
+			return &RegistryError{StatusCode: code, Message: text}
+		}
+	}
+
+	return err
+}
+
+// IsNotFound reports whether err means that the subject, or the requested
+// version of it, does not exist in the registry. Soft-deleted subjects are
+// reported as not found by the registry as well, except that soft deleting
+// them again reports them as soft deleted.
+func IsNotFound(err error) bool {
+	err = ClassifyError(err)
+	return errors.Is(err, ErrSubjectNotFound) || errors.Is(err, ErrVersionNotFound) ||
+		errors.Is(err, ErrSubjectSoftDeleted)
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/riferrei/srclient"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "subject not found", err: srclient.Error{Code: 40401, Message: "Subject not found."}, want: ErrSubjectNotFound},
+		{name: "version not found", err: srclient.Error{Code: 40402}, want: ErrVersionNotFound},
+		{name: "schema not found", err: srclient.Error{Code: 40403}, want: ErrSchemaNotFound},
+		{name: "subject soft deleted", err: srclient.Error{Code: 40404}, want: ErrSubjectSoftDeleted},
+		{name: "config not found", err: srclient.Error{Code: 40408}, want: ErrConfigNotFound},
+		{name: "mode not found", err: srclient.Error{Code: 40409}, want: ErrModeNotFound},
+		{name: "semantic lookup miss", err: srclient.ErrSemanticSchemaNotFound, want: ErrSchemaNotFound},
+		{name: "incompatible", err: srclient.Error{Code: 409}, want: ErrIncompatibleSchema},
+		{name: "invalid schema", err: srclient.Error{Code: 42201}, want: ErrInvalidSchema},
+		{name: "invalid compatibility level", err: srclient.Error{Code: 42203}, want: ErrInvalidCompatibilityLevel},
+		{name: "invalid mode", err: srclient.Error{Code: 42204}, want: ErrInvalidMode},
+		{name: "operation not permitted", err: srclient.Error{Code: 42205}, want: ErrOperationNotPermitted},
+		{name: "reference exists", err: srclient.Error{Code: 42206}, want: ErrReferenceExists},
+		{name: "unauthorized", err: srclient.Error{Code: 401}, want: ErrUnauthorized},
+		{name: "forbidden detail code", err: srclient.Error{Code: 40301}, want: ErrForbidden},
+		{name: "server error", err: srclient.Error{Code: 50001}, want: ErrServerError},
+		{name: "wrapped", err: fmt.Errorf("failed to lookup schema: %w", srclient.Error{Code: 40401}), want: ErrSubjectNotFound},
+		{name: "status only server error", err: errors.New("503 Service Unavailable"), want: ErrServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyError(tt.err)
+			if !errors.Is(got, tt.want) {
+				t.Errorf("ClassifyError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyError_transport(t *testing.T) {
+	err := errors.New("dial tcp 127.0.0.1:8081: connect: connection refused")
+
+	var regErr *RegistryError
+	if errors.As(ClassifyError(err), &regErr) {
+		t.Errorf("ClassifyError(%v) returned a registry error", err)
+	}
+}
+
+func TestClassifyError_statusOnly(t *testing.T) {
+	// A bare status, e.g. from a wrong URL or a gateway, does not say what
+	// is missing or conflicting
+	for _, err := range []error{errors.New("404 Not Found"), errors.New("409 Conflict")} {
+		got := ClassifyError(err)
+		if IsNotFound(got) || errors.Is(got, ErrIncompatibleSchema) {
+			t.Errorf("ClassifyError(%v) = %v, want an unclassified registry error", err, got)
+		}
+	}
+}
+
+func TestClient_notFoundWithoutErrorCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("<html><body>404 page not found</body></html>"))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.URL, server.Client())
+	_, err := client.GetSubjectVersion(context.Background(), "orders-value", "latest", false)
+	if err == nil {
+		t.Fatal("GetSubjectVersion() error = nil, want an error")
+	}
+	if IsNotFound(err) {
+		t.Errorf("IsNotFound(%v) = true, want false for a response without a registry error code", err)
+	}
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+)
+
+// throttleLogThreshold is how long a request has to wait for the limiter
+// before the wait is logged.
+const throttleLogThreshold = 10 * time.Millisecond
+
+// LimitTransport is an http.RoundTripper that limits the rate and the number
+// of concurrent requests passed to Base. A request holds its concurrency slot
+// until its response body is closed. Transports sharing a Limiter or
+// Concurrency share their limits.
+type LimitTransport struct {
+	Base http.RoundTripper
+	// Limiter limits the request rate. Nil means no limit.
+	Limiter *rate.Limiter
+	// Concurrency limits the number of requests in flight. Nil means no limit.
+	Concurrency *semaphore.Weighted
+}
+
+// NewRateLimiter returns a limiter allowing requestsPerSecond requests per
+// second on average, in bursts of up to one second's worth of requests.
+func NewRateLimiter(requestsPerSecond float64) *rate.Limiter {
+	burst := max(int(math.Ceil(requestsPerSecond)), 1)
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *LimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	closeBody := func() {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+	}
+
+	if t.Concurrency != nil {
+		start := time.Now()
+		if err := t.Concurrency.Acquire(ctx, 1); err != nil {
+			closeBody()
+			return nil, err
+		}
+		logThrottled(ctx, req, "concurrency", time.Since(start))
+	}
+	release := sync.OnceFunc(func() {
+		if t.Concurrency != nil {
+			t.Concurrency.Release(1)
+		}
+	})
+
+	if t.Limiter != nil {
+		start := time.Now()
+		if err := t.Limiter.Wait(ctx); err != nil {
+			release()
+			closeBody()
+			return nil, err
+		}
+		logThrottled(ctx, req, "rate", time.Since(start))
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// logThrottled logs how long req waited for the given limit, when it waited
+// long enough to matter.
+func logThrottled(ctx context.Context, req *http.Request, limit string, waited time.Duration) {
+	if waited < throttleLogThreshold {
+		return
+	}
+	tflog.Debug(ctx, "Schema Registry request throttled by client-side limit", map[string]any{
+		"limit":  limit,
+		"method": req.Method,
+		"path":   req.URL.Path,
+		"waited": waited.String(),
+	})
+}
+
+// releaseOnClose calls release once when the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+)
+
+// testSlowServer starts a server that takes delay to answer and records the
+// highest number of requests it was handling at once.
+func testSlowServer(t *testing.T, delay time.Duration) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			highest := maxInFlight.Load()
+			if n <= highest || maxInFlight.CompareAndSwap(highest, n) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &maxInFlight
+}
+
+// listSubjectsConcurrently lists subjects n times at once and fails the test on
+// errors.
+func listSubjectsConcurrently(t *testing.T, client *Client, n int) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.ListSubjects(context.Background(), "", false); err != nil {
+				t.Errorf("ListSubjects() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLimitTransport_concurrency(t *testing.T) {
+	server, maxInFlight := testSlowServer(t, 20*time.Millisecond)
+	client := NewClient(server.URL, &http.Client{Transport: &LimitTransport{Concurrency: semaphore.NewWeighted(2)}})
+
+	listSubjectsConcurrently(t, client, 10)
+
+	if got := maxInFlight.Load(); got > 2 {
+		t.Errorf("registry handled %d requests at once, want at most 2", got)
+	}
+}
+
+func TestLimitTransport_rate(t *testing.T) {
+	server, _ := testSlowServer(t, 0)
+	client := NewClient(server.URL, &http.Client{
+		Transport: &LimitTransport{Limiter: rate.NewLimiter(rate.Limit(20), 1)},
+	})
+
+	start := time.Now()
+	listSubjectsConcurrently(t, client, 5)
+
+	// The first request is sent at once, the other four 50ms apart
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("5 requests at 20 per second took %s, want at least 200ms", elapsed)
+	}
+}
+
+func TestLimitTransport_contextCanceled(t *testing.T) {
+	server, _ := testSlowServer(t, 0)
+	concurrency := semaphore.NewWeighted(1)
+	client := NewClient(server.URL, &http.Client{Transport: &LimitTransport{Concurrency: concurrency}})
+
+	// Another request holds the only slot
+	if err := concurrency.Acquire(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	defer concurrency.Release(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.ListSubjects(ctx, "", false); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ListSubjects() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	tests := []struct {
+		requestsPerSecond float64
+		wantBurst         int
+	}{
+		{requestsPerSecond: 0.5, wantBurst: 1},
+		{requestsPerSecond: 10, wantBurst: 10},
+		{requestsPerSecond: 2.5, wantBurst: 3},
+	}
+
+	for _, tt := range tests {
+		if got := NewRateLimiter(tt.requestsPerSecond).Burst(); got != tt.wantBurst {
+			t.Errorf("NewRateLimiter(%v).Burst() = %d, want %d", tt.requestsPerSecond, got, tt.wantBurst)
+		}
+	}
+}
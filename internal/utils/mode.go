@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Registry modes.
+const (
+	ModeReadWrite        = "READWRITE"
+	ModeReadOnly         = "READONLY"
+	ModeReadOnlyOverride = "READONLY_OVERRIDE"
+	ModeImport           = "IMPORT"
+)
+
+// modeBody is the request and response body of the mode endpoints.
+type modeBody struct {
+	Mode string `json:"mode"`
+}
+
+// modePath returns the mode endpoint for a subject, or the global mode
+// endpoint when subject is empty.
+func modePath(subject string) string {
+	if subject == "" {
+		return "/mode"
+	}
+	return subjectPath("/mode/%s", subject)
+}
+
+// GetMode returns the mode of a subject, or the global mode when subject is
+// empty:
+//
+//	GET /mode/{subject}?defaultToGlobal={defaultToGlobal}
+func (c *Client) GetMode(ctx context.Context, subject string, defaultToGlobal bool) (string, error) {
+	var query url.Values
+	if subject != "" {
+		query = url.Values{"defaultToGlobal": {strconv.FormatBool(defaultToGlobal)}}
+	}
+
+	var body modeBody
+	if err := c.do(ctx, http.MethodGet, modePath(subject), query, nil, &body); err != nil {
+		return "", err
+	}
+
+	return body.Mode, nil
+}
+
+// UpdateMode sets the mode of a subject, or the global mode when subject is
+// empty. Force allows switching to IMPORT mode while schemas exist:
+//
+//	PUT /mode/{subject}?force={force}
+func (c *Client) UpdateMode(ctx context.Context, subject, mode string, force bool) error {
+	var query url.Values
+	if force {
+		query = url.Values{"force": {"true"}}
+	}
+
+	return c.do(ctx, http.MethodPut, modePath(subject), query, modeBody{Mode: mode}, nil)
+}
+
+// DeleteMode removes the mode of a subject so that it falls back to the
+// global mode:
+//
+//	DELETE /mode/{subject}
+func (c *Client) DeleteMode(ctx context.Context, subject string) error {
+	return c.do(ctx, http.MethodDelete, modePath(subject), nil, nil, nil)
+}
@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// protoPunctuation lists the characters that always form a token on their own.
+const protoPunctuation = "{}[]()<>;=,:"
+
+// protoNode is a single statement of a Protobuf definition. Statements that
+// open a block (message, enum, service, ...) carry their nested statements.
+type protoNode struct {
+	head     []string
+	block    bool
+	children []*protoNode
+}
+
+// canonicalProtobuf returns a representation of a Protobuf definition that
+// ignores whitespace, comments and the order of declarations. Enum bodies keep
+// their order because the first value is the proto2 default.
+func canonicalProtobuf(s string) (string, error) {
+	tokens, err := tokenizeProtobuf(s)
+	if err != nil {
+		return "", err
+	}
+
+	pos := 0
+	root, err := parseProtoBlock(tokens, &pos, false)
+	if err != nil {
+		return "", err
+	}
+
+	return root.render(true), nil
+}
+
+// parseProtoBlock parses statements until the closing brace of a nested block
+// or, for the top level, the end of input.
+func parseProtoBlock(tokens []string, pos *int, nested bool) (*protoNode, error) {
+	node := &protoNode{block: true}
+	var head []string
+
+	for *pos < len(tokens) {
+		tok := tokens[*pos]
+		*pos++
+
+		switch tok {
+		case ";":
+			if len(head) > 0 {
+				node.children = append(node.children, &protoNode{head: head})
+				head = nil
+			}
+		case "{":
+			child, err := parseProtoBlock(tokens, pos, true)
+			if err != nil {
+				return nil, err
+			}
+			child.head = head
+			node.children = append(node.children, child)
+			head = nil
+		case "}":
+			if !nested {
+				return nil, errors.New("unbalanced '}' in protobuf definition")
+			}
+			if len(head) > 0 {
+				// Aggregate option values do not terminate their fields with ';'.
+				node.children = append(node.children, &protoNode{head: head})
+			}
+			return node, nil
+		default:
+			head = append(head, tok)
+		}
+	}
+
+	if nested {
+		return nil, errors.New("missing '}' in protobuf definition")
+	}
+	if len(head) > 0 {
+		return nil, errors.New("unterminated statement in protobuf definition")
+	}
+	return node, nil
+}
+
+// render writes the node in canonical form.
+func (n *protoNode) render(sortChildren bool) string {
+	head := strings.Join(n.head, " ")
+	if !n.block {
+		return head + ";"
+	}
+
+	children := make([]string, len(n.children))
+	for i, child := range n.children {
+		children[i] = child.render(len(child.head) == 0 || child.head[0] != "enum")
+	}
+	if sortChildren {
+		sort.Strings(children)
+	}
+
+	return head + "{" + strings.Join(children, "") + "}"
+}
+
+// tokenizeProtobuf splits a Protobuf definition into tokens, dropping
+// whitespace and comments.
+func tokenizeProtobuf(s string) ([]string, error) {
+	var tokens []string
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			j := i + 2
+			for j+1 < len(runes) && (runes[j] != '*' || runes[j+1] != '/') {
+				j++
+			}
+			if j+1 >= len(runes) {
+				return nil, errors.New("unterminated comment in protobuf definition")
+			}
+			i = j + 2
+		case r == '"' || r == '\'':
+			j := i + 1
+			for j < len(runes) && runes[j] != r {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(runes) {
+				return nil, errors.New("unterminated string in protobuf definition")
+			}
+			tokens = append(tokens, string(runes[i:j+1]))
+			i = j + 1
+		case strings.ContainsRune(protoPunctuation, r):
+			tokens = append(tokens, string(r))
+			i++
+		default:
+			j := i
+			for j < len(runes) && !unicode.IsSpace(runes[j]) && !strings.ContainsRune(protoPunctuation+`"'`, runes[j]) &&
+				(runes[j] != '/' || j+1 >= len(runes) || (runes[j+1] != '/' && runes[j+1] != '*')) {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		}
+	}
+
+	return tokens, nil
+}
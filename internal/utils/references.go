@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+// GetReferencedBy returns the IDs of the schemas that reference a version of
+// a subject:
+//
+//	GET /subjects/{subject}/versions/{version}/referencedby
+func (c *Client) GetReferencedBy(ctx context.Context, subject, version string) ([]int, error) {
+	path := subjectPath("/subjects/%s/versions/%s/referencedby", subject, url.PathEscape(version))
+
+	var ids []int
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &ids); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// GetReferencingSubjectVersions resolves the schemas that reference a version
+// of a subject into the subject versions that use them, sorted by subject and
+// version.
+func (c *Client) GetReferencingSubjectVersions(ctx context.Context, subject, version string) ([]int, []SubjectVersion, error) {
+	ids, err := c.GetReferencedBy(ctx, subject, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var referencing []SubjectVersion
+	for _, id := range ids {
+		versions, err := c.GetSchemaSubjectVersions(ctx, id, subject)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, v := range versions {
+			if !slices.Contains(referencing, v) {
+				referencing = append(referencing, v)
+			}
+		}
+	}
+
+	slices.SortFunc(referencing, func(a, b SubjectVersion) int {
+		return cmp.Or(cmp.Compare(a.Subject, b.Subject), cmp.Compare(a.Version, b.Version))
+	})
+	return ids, referencing, nil
+}
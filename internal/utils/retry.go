@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRetryableStatusCodes are the HTTP status codes retried when no
+// others are configured.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy controls how failed requests are retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry. It doubles with every
+	// further retry.
+	BaseDelay time.Duration
+	// MaxWait caps the delay between two attempts, including delays requested
+	// by the registry with Retry-After.
+	MaxWait time.Duration
+	// RetryableStatusCodes are the response status codes that are retried.
+	RetryableStatusCodes []int
+}
+
+// RetryTransport is an http.RoundTripper that retries requests failing with
+// a connection error or a retryable status code, using jittered exponential
+// backoff and honouring Retry-After.
+//
+// Requests that are not idempotent are only retried when the request cannot
+// have been processed: when the connection could not be established, or the
+// registry answered 429 Too Many Requests or 503 Service Unavailable.
+type RetryTransport struct {
+	Base   http.RoundTripper
+	Policy RetryPolicy
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// Requests whose body cannot be replayed are sent once
+	rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	idempotent := isIdempotent(req)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := base.RoundTrip(attemptReq)
+		if attempt >= t.Policy.MaxRetries || !rewindable || !t.shouldRetry(resp, err, idempotent) {
+			return resp, err
+		}
+
+		delay := t.delay(attempt, resp)
+		if resp != nil {
+			// Drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether an attempt that returned resp and err is
+// retried.
+func (t *RetryTransport) shouldRetry(resp *http.Response, err error, idempotent bool) bool {
+	if err != nil {
+		var opErr *net.OpError
+		notConnected := errors.As(err, &opErr) && opErr.Op == "dial"
+		return idempotent || notConnected
+	}
+
+	if !idempotent && resp.StatusCode != http.StatusTooManyRequests &&
+		resp.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+
+	codes := t.Policy.RetryableStatusCodes
+	if codes == nil {
+		codes = DefaultRetryableStatusCodes
+	}
+	for _, code := range codes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns how long to wait after the given attempt: the jittered
+// exponential backoff, or the registry's Retry-After when it is longer,
+// capped by MaxWait.
+func (t *RetryTransport) delay(attempt int, resp *http.Response) time.Duration {
+	backoff := t.Policy.BaseDelay << attempt
+	if backoff <= 0 || (t.Policy.MaxWait > 0 && backoff > t.Policy.MaxWait) {
+		backoff = t.Policy.MaxWait
+	}
+	// Equal jitter keeps at least half of the backoff
+	if backoff > 1 {
+		backoff = backoff/2 + rand.N(backoff/2) //nolint:gosec // jitter does not need a secure source
+	}
+
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok && retryAfter > backoff {
+			backoff = retryAfter
+		}
+	}
+	if t.Policy.MaxWait > 0 && backoff > t.Policy.MaxWait {
+		backoff = t.Policy.MaxWait
+	}
+	return backoff
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP
+// date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// isIdempotent reports whether req can safely be sent more than once. Besides
+// the idempotent HTTP methods and requests with an Idempotency-Key header,
+// this includes the registry's read-only POST endpoints: schema lookups
+// (POST /subjects/{subject}) and compatibility checks (POST /compatibility/...).
+// Registrations are not included, since a retried registration may create a
+// version that another writer has superseded in the meantime.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		path := req.URL.EscapedPath()
+		if strings.Contains(path, "/compatibility/") {
+			return true
+		}
+		if _, subject, ok := strings.Cut(path, "/subjects/"); ok && subject != "" && !strings.Contains(subject, "/") {
+			return true
+		}
+	}
+
+	_, hasKey := req.Header["Idempotency-Key"]
+	return hasKey
+}
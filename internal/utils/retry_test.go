@@ -0,0 +1,226 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testFlakyServer starts a server that answers the first failures requests
+// with status and the given Retry-After header, then succeeds. It counts the
+// requests it received and checks that retried requests still carry their
+// body.
+func testFlakyServer(t *testing.T, failures int32, status int, retryAfter string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		if r.Method == http.MethodPost {
+			if body, _ := io.ReadAll(r.Body); string(body) != `{"schema":"{}"}` {
+				t.Errorf("request %d body = %q, want the original body", n, body)
+			}
+		}
+		if n <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxWait: 50 * time.Millisecond}
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		status       int
+		failures     int32
+		policy       func(*RetryPolicy)
+		wantStatus   int
+		wantRequests int32
+	}{
+		{
+			name: "retried until success", method: http.MethodGet, path: "/subjects",
+			status: http.StatusServiceUnavailable, failures: 2,
+			wantStatus: http.StatusOK, wantRequests: 3,
+		},
+		{
+			name: "lookup POST retried", method: http.MethodPost, path: "/subjects/orders-value",
+			status: http.StatusBadGateway, failures: 1,
+			wantStatus: http.StatusOK, wantRequests: 2,
+		},
+		{
+			name: "lookup POST of escaped subject retried", method: http.MethodPost, path: "/subjects/team%2Forders",
+			status: http.StatusBadGateway, failures: 1,
+			wantStatus: http.StatusOK, wantRequests: 2,
+		},
+		{
+			name:   "compatibility POST retried",
+			method: http.MethodPost, path: "/compatibility/subjects/orders-value/versions/latest",
+			status: http.StatusBadGateway, failures: 1,
+			wantStatus: http.StatusOK, wantRequests: 2,
+		},
+		{
+			name: "registration POST not retried on bad gateway", method: http.MethodPost,
+			path: "/subjects/orders-value/versions", status: http.StatusBadGateway, failures: 1,
+			wantStatus: http.StatusBadGateway, wantRequests: 1,
+		},
+		{
+			name: "registration POST retried on too many requests", method: http.MethodPost,
+			path: "/subjects/orders-value/versions", status: http.StatusTooManyRequests, failures: 1,
+			wantStatus: http.StatusOK, wantRequests: 2,
+		},
+		{
+			name: "other POST not retried on bad gateway", method: http.MethodPost, path: "/exporters",
+			status: http.StatusBadGateway, failures: 1,
+			wantStatus: http.StatusBadGateway, wantRequests: 1,
+		},
+		{
+			name: "other POST retried on too many requests", method: http.MethodPost, path: "/exporters",
+			status: http.StatusTooManyRequests, failures: 1,
+			wantStatus: http.StatusOK, wantRequests: 2,
+		},
+		{
+			name: "status not retryable", method: http.MethodGet, path: "/subjects",
+			status: http.StatusInternalServerError, failures: 1,
+			wantStatus: http.StatusInternalServerError, wantRequests: 1,
+		},
+		{
+			name: "custom retryable status", method: http.MethodGet, path: "/subjects",
+			status: http.StatusInternalServerError, failures: 1,
+			policy:     func(p *RetryPolicy) { p.RetryableStatusCodes = []int{http.StatusInternalServerError} },
+			wantStatus: http.StatusOK, wantRequests: 2,
+		},
+		{
+			name: "retries exhausted", method: http.MethodGet, path: "/subjects",
+			status: http.StatusServiceUnavailable, failures: 10,
+			wantStatus: http.StatusServiceUnavailable, wantRequests: 4,
+		},
+		{
+			name: "retries disabled", method: http.MethodGet, path: "/subjects",
+			status: http.StatusServiceUnavailable, failures: 1,
+			policy:     func(p *RetryPolicy) { p.MaxRetries = 0 },
+			wantStatus: http.StatusServiceUnavailable, wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := testFlakyServer(t, tt.failures, tt.status, "")
+
+			policy := testRetryPolicy()
+			if tt.policy != nil {
+				tt.policy(&policy)
+			}
+			client := &http.Client{Transport: &RetryTransport{Policy: policy}}
+
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader(`{"schema":"{}"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("sent %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_retryAfter(t *testing.T) {
+	server, requests := testFlakyServer(t, 1, http.StatusTooManyRequests, "1")
+
+	policy := testRetryPolicy()
+	policy.MaxWait = 5 * time.Second
+	client := NewClient(server.URL, &http.Client{Transport: &RetryTransport{Policy: policy}})
+
+	start := time.Now()
+	if _, err := client.ListSubjects(context.Background(), "", false); err != nil {
+		t.Fatalf("ListSubjects() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least the Retry-After of 1s", elapsed)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("sent %d requests, want 2", got)
+	}
+}
+
+func TestRetryTransport_maxWait(t *testing.T) {
+	server, _ := testFlakyServer(t, 1, http.StatusTooManyRequests, "3600")
+	client := NewClient(server.URL, &http.Client{Transport: &RetryTransport{Policy: testRetryPolicy()}})
+
+	start := time.Now()
+	if _, err := client.ListSubjects(context.Background(), "", false); err != nil {
+		t.Fatalf("ListSubjects() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("retried after %s, want the Retry-After capped by MaxWait", elapsed)
+	}
+}
+
+func TestRetryTransport_contextCanceled(t *testing.T) {
+	server, requests := testFlakyServer(t, 10, http.StatusServiceUnavailable, "3600")
+
+	policy := testRetryPolicy()
+	policy.MaxWait = time.Hour
+	client := NewClient(server.URL, &http.Client{Transport: &RetryTransport{Policy: policy}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := client.ListSubjects(ctx, "", false)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ListSubjects() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("sent %d requests, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: "", wantOK: false},
+		{value: "120", want: 2 * time.Minute, wantOK: true},
+		{value: "-1", wantOK: false},
+		{value: "soon", wantOK: false},
+		{value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %s, %t, want %s, %t", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SchemasEqual reports whether two schema definitions of the given schema type
+// ("AVRO", "JSON" or "PROTOBUF") are equal once formatting is ignored.
+// Definitions that cannot be parsed are compared byte for byte.
+func SchemasEqual(schemaType, a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	canonical := canonicalJSON
+	if schemaType == "PROTOBUF" {
+		canonical = canonicalProtobuf
+	}
+
+	ca, err := canonical(a)
+	if err != nil {
+		return false
+	}
+	cb, err := canonical(b)
+	if err != nil {
+		return false
+	}
+
+	return ca == cb
+}
+
+// canonicalJSON re-encodes a JSON document with sorted keys and no
+// insignificant whitespace, preserving number representations.
+func canonicalJSON(s string) (string, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+
+	var temp any
+	if err := dec.Decode(&temp); err != nil {
+		return "", err
+	}
+
+	out, err := json.Marshal(&temp)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,101 @@
+package utils
+
+import "testing"
+
+const testProtobufSchema = `syntax = "proto3";
+package com.example;
+
+import "google/protobuf/timestamp.proto";
+
+// An order placed by a customer.
+message Order {
+  string id = 1;
+  google.protobuf.Timestamp created_at = 2;
+  repeated Item items = 3 [deprecated = true];
+
+  message Item {
+    string sku = 1;
+    int32 quantity = 2;
+  }
+}
+
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_OPEN = 1;
+}
+`
+
+func TestSchemasEqual(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		a          string
+		b          string
+		want       bool
+	}{
+		{
+			name:       "json whitespace and key order",
+			schemaType: "AVRO",
+			a:          `{"type": "record", "name": "Test", "fields": [{"name": "f1", "type": "string"}]}`,
+			b:          "{\n  \"name\": \"Test\",\n  \"type\": \"record\",\n  \"fields\": [{\"type\": \"string\", \"name\": \"f1\"}]\n}",
+			want:       true,
+		},
+		{
+			name:       "json field order is significant",
+			schemaType: "JSON",
+			a:          `{"fields": [{"name": "a"}, {"name": "b"}]}`,
+			b:          `{"fields": [{"name": "b"}, {"name": "a"}]}`,
+			want:       false,
+		},
+		{
+			name:       "protobuf whitespace, comments and ordering",
+			schemaType: "PROTOBUF",
+			a:          testProtobufSchema,
+			b: `syntax = "proto3"; package com.example; /* leading */ import "google/protobuf/timestamp.proto";
+enum Status { STATUS_UNSPECIFIED = 0; STATUS_OPEN = 1; }
+message Order {
+  message Item { int32 quantity = 2; string sku = 1; } // nested first
+  repeated Item items = 3 [ deprecated = true ];
+  google.protobuf.Timestamp created_at = 2;
+  string id = 1;
+}`,
+			want: true,
+		},
+		{
+			name:       "protobuf enum order is significant",
+			schemaType: "PROTOBUF",
+			a:          `enum Status { A = 0; B = 1; }`,
+			b:          `enum Status { B = 1; A = 0; }`,
+			want:       false,
+		},
+		{
+			name:       "protobuf field number change",
+			schemaType: "PROTOBUF",
+			a:          `message Order { string id = 1; }`,
+			b:          `message Order { string id = 2; }`,
+			want:       false,
+		},
+		{
+			name:       "protobuf comment markers inside strings",
+			schemaType: "PROTOBUF",
+			a:          `option go_package = "example.com/a//b";`,
+			b:          `option go_package = "example.com/a/b";`,
+			want:       false,
+		},
+		{
+			name:       "unparseable definitions fall back to exact comparison",
+			schemaType: "PROTOBUF",
+			a:          `message Order { string id = 1;`,
+			b:          `message Order {string id = 1;`,
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SchemasEqual(tt.schemaType, tt.a, tt.b); got != tt.want {
+				t.Errorf("SchemasEqual() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
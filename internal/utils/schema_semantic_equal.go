@@ -3,41 +3,31 @@ package utils
 import (
 	"context"
 	"errors"
-
-	"github.com/riferrei/srclient"
 )
 
 // IsSemanticallyEqual checks if a given schema is semantically equivalent to
-// any existing schema under the specified subject in the Schema Registry.  It
+// any existing schema under the specified subject in the Schema Registry. It
 // returns true when the lookup succeeds, false when the registry replies 40403
-// (ErrSemanticSchemaNotFound), and an error for anything else.
+// (ErrSchemaNotFound), and an error for anything else.
 //
-// The function uses the Schema Registry's lookup functionality with
-// normalization enabled to determine semantic equivalence. Two schemas are
-// considered semantically equal if they have the same structure and meaning,
-// even if they differ in formatting, field ordering, or other non-semantic
-// aspects.
-func IsSemanticallyEqual(
-	ctx context.Context,
-	client *srclient.SchemaRegistryClient,
-	subject string,
-	schemaString string,
-	schemaType srclient.SchemaType,
-	refs []srclient.Reference,
-) (bool, error) {
-	req := &srclient.RegisterSchemaRequest{
-		Schema:     schemaString,
-		SchemaType: schemaType,
-		References: refs,
-	}
-	// Lookup schema with `normalize = true` We only care about the error, but
-	// need to handle all return values
-	_, _, _, err := client.LookupSchemaUnderSubject(ctx, subject, req, true) //nolint:dogsled
+// The function uses the Schema Registry's lookup functionality to determine
+// semantic equivalence. With normalize set, two schemas are considered
+// semantically equal if they have the same structure and meaning, even if
+// they differ in formatting, field ordering, or other non-semantic aspects;
+// it should match the normalization used when registering the schema.
+//
+// When the request carries metadata or a rule set, the matching version must
+// also have the same metadata or rule set, since changing either registers a
+// new version.
+func IsSemanticallyEqual(ctx context.Context, client *Client, subject string, req SchemaRequest,
+	normalize bool) (bool, error) {
+	registered, err := client.LookupSchema(ctx, subject, req, normalize)
 
 	switch {
 	case err == nil:
-		return true, nil
-	case errors.Is(err, srclient.ErrSemanticSchemaNotFound):
+		return (req.Metadata == nil || MetadataEqual(req.Metadata, registered.Metadata)) &&
+			(req.RuleSet == nil || RuleSetEqual(req.RuleSet, registered.RuleSet)), nil
+	case errors.Is(err, ErrSchemaNotFound):
 		return false, nil
 
 	default:
@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/riferrei/srclient"
+)
+
+// RegisteredSchema is a schema as returned by the registry. Subject and
+// Version are only set when the schema was fetched through a subject.
+type RegisteredSchema struct {
+	Subject    string               `json:"subject,omitempty"`
+	Version    int                  `json:"version,omitempty"`
+	ID         int                  `json:"id,omitempty"`
+	Schema     string               `json:"schema"`
+	SchemaType string               `json:"schemaType,omitempty"`
+	References []srclient.Reference `json:"references,omitempty"`
+	Metadata   *Metadata            `json:"metadata,omitempty"`
+	RuleSet    *RuleSet             `json:"ruleSet,omitempty"`
+}
+
+// Type returns the schema format. The registry omits the type for AVRO.
+func (s *RegisteredSchema) Type() string {
+	if s.SchemaType == "" {
+		return "AVRO"
+	}
+	return s.SchemaType
+}
+
+// GetSchemaByID returns the schema registered under an ID in the schema
+// context of subject, which may be unqualified or only a context prefix such
+// as `:.team-a:`:
+//
+//	GET /schemas/ids/{id}?subject={context}
+func (c *Client) GetSchemaByID(ctx context.Context, id int, subject string) (*RegisteredSchema, error) {
+	var schema RegisteredSchema
+	path := "/schemas/ids/" + strconv.Itoa(id)
+	if err := c.do(ctx, http.MethodGet, path, contextQuery(subject), nil, &schema); err != nil {
+		return nil, err
+	}
+
+	schema.ID = id
+	return &schema, nil
+}
+
+// SubjectVersion is a subject and version pair that uses a schema.
+type SubjectVersion struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// GetSchemaSubjectVersions returns every subject and version that uses a
+// schema ID in the schema context of subject:
+//
+//	GET /schemas/ids/{id}/versions?subject={context}
+func (c *Client) GetSchemaSubjectVersions(ctx context.Context, id int, subject string) ([]SubjectVersion, error) {
+	var versions []SubjectVersion
+	path := "/schemas/ids/" + strconv.Itoa(id) + "/versions"
+	if err := c.do(ctx, http.MethodGet, path, contextQuery(subject), nil, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// GetSubjectVersion returns a version of a subject, which may be `latest`.
+// Soft-deleted versions are only returned when deleted is set:
+//
+//	GET /subjects/{subject}/versions/{version}?deleted={deleted}
+func (c *Client) GetSubjectVersion(ctx context.Context, subject, version string, deleted bool) (*RegisteredSchema, error) {
+	path := subjectPath("/subjects/%s/versions/%s", subject, url.PathEscape(version))
+
+	var query url.Values
+	if deleted {
+		query = url.Values{"deleted": {"true"}}
+	}
+
+	var schema RegisteredSchema
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &schema); err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+// WaitForSubjectVersion is GetSubjectVersion retried with the read-back
+// delays while the registry reports the subject or version missing, so that a
+// schema that was just registered can be read back.
+func (c *Client) WaitForSubjectVersion(ctx context.Context, subject, version string) (*RegisteredSchema, error) {
+	for attempt := 0; ; attempt++ {
+		schema, err := c.GetSubjectVersion(ctx, subject, version, false)
+		if err == nil || !IsNotFound(err) || attempt >= len(c.readBackDelays) {
+			return schema, err
+		}
+
+		timer := time.NewTimer(c.readBackDelays[attempt])
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// LookupSchema returns the version of subject that matches the schema in req,
+// normalizing both first when normalize is set:
+//
+//	POST /subjects/{subject}?normalize={normalize}
+func (c *Client) LookupSchema(ctx context.Context, subject string, req SchemaRequest,
+	normalize bool) (*RegisteredSchema, error) {
+	var query url.Values
+	if normalize {
+		query = url.Values{"normalize": {"true"}}
+	}
+
+	var schema RegisteredSchema
+	if err := c.do(ctx, http.MethodPost, subjectPath("/subjects/%s", subject), query, req, &schema); err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+// ListSubjects returns the registered subjects starting with prefix, including
+// soft-deleted subjects when deleted is set:
+//
+//	GET /subjects?subjectPrefix={prefix}&deleted={deleted}
+func (c *Client) ListSubjects(ctx context.Context, prefix string, deleted bool) ([]string, error) {
+	query := url.Values{}
+	if prefix != "" {
+		query.Set("subjectPrefix", prefix)
+	}
+	if deleted {
+		query.Set("deleted", "true")
+	}
+
+	var subjects []string
+	if err := c.do(ctx, http.MethodGet, "/subjects", query, nil, &subjects); err != nil {
+		return nil, err
+	}
+
+	return subjects, nil
+}
+
+// ListSubjectVersions returns the versions of a subject, including
+// soft-deleted versions when deleted is set:
+//
+//	GET /subjects/{subject}/versions?deleted={deleted}
+func (c *Client) ListSubjectVersions(ctx context.Context, subject string, deleted bool) ([]int, error) {
+	var query url.Values
+	if deleted {
+		query = url.Values{"deleted": {"true"}}
+	}
+
+	var versions []int
+	if err := c.do(ctx, http.MethodGet, subjectPath("/subjects/%s/versions", subject), query, nil, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// DeleteSubject soft-deletes a subject, then permanently deletes it when
+// permanent is set, as the registry only permanently deletes soft-deleted
+// subjects. A subject that was already soft deleted, e.g. by an earlier
+// failed apply, goes straight to the permanent delete:
+//
+//	DELETE /subjects/{subject}?permanent={permanent}
+func (c *Client) DeleteSubject(ctx context.Context, subject string, permanent bool) error {
+	path := subjectPath("/subjects/%s", subject)
+	err := c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+	if !permanent || (err != nil && !errors.Is(err, ErrSubjectSoftDeleted)) {
+		return err
+	}
+
+	return c.do(ctx, http.MethodDelete, path, url.Values{"permanent": {"true"}}, nil, nil)
+}
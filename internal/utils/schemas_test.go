@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testLaggingServer starts a registry that reports a subject version missing
+// for the first misses requests, as an instance that has not caught up with a
+// registration yet would.
+func testLaggingServer(t *testing.T, misses int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= misses {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code":40402,"message":"Version not found."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"subject":"orders-value","version":1,"id":7,"schema":"\"string\""}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestWaitForSubjectVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		misses       int32
+		wantErr      error
+		wantRequests int32
+	}{
+		{name: "found at once", misses: 0, wantRequests: 1},
+		{name: "found after retries", misses: 2, wantRequests: 3},
+		{name: "still missing", misses: 10, wantErr: ErrVersionNotFound, wantRequests: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := testLaggingServer(t, tt.misses)
+			client := NewClient(server.URL, server.Client())
+			client.SetRetryDelays([]time.Duration{time.Millisecond, time.Millisecond, time.Millisecond})
+
+			schema, err := client.WaitForSubjectVersion(context.Background(), "orders-value", "latest")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("WaitForSubjectVersion() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("WaitForSubjectVersion() error = %v", err)
+			} else if schema.ID != 7 || schema.Version != 1 {
+				t.Errorf("WaitForSubjectVersion() = ID %d version %d, want ID 7 version 1", schema.ID, schema.Version)
+			}
+
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("sent %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestWaitForSubjectVersion_contextCanceled(t *testing.T) {
+	server, _ := testLaggingServer(t, 10)
+	client := NewClient(server.URL, server.Client())
+	client.SetRetryDelays([]time.Duration{time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.WaitForSubjectVersion(ctx, "orders-value", "latest"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitForSubjectVersion() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -1,22 +1,20 @@
 package utils
 
 import (
+	"context"
 	"fmt"
-	"strings"
-
-	"github.com/riferrei/srclient"
 )
 
 // IsSubjectManaged prevents multiple Terraform resources from managing the same subject.
-func IsSubjectManaged(client *srclient.SchemaRegistryClient, subject string) error {
+func IsSubjectManaged(ctx context.Context, client *Client, subject string) error {
 	// Fetch the subject-specific versions from the schema registry:
 	//   GET /subjects/{subject}/versions
-	versions, err := client.GetSchemaVersions(subject)
+	versions, err := client.ListSubjectVersions(ctx, subject, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if IsNotFound(err) {
 			return nil
 		}
-		return fmt.Errorf("error checking existence of subject %q: %w", subject, err)
+		return fmt.Errorf("error checking existence of subject %q: %w", subject, ClassifyError(err))
 	}
 	// If one or more subject versions exist, return an error
 	if len(versions) > 0 {
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TLSOptions configures TLS for connections to the registry. Certificates and
+// keys are PEM encoded.
+type TLSOptions struct {
+	// CACert is a CA certificate bundle used to verify the registry.
+	CACert string
+	// CACertFile is the path of a CA certificate bundle used to verify the
+	// registry.
+	CACertFile string
+	// ClientCert is the client certificate, or the path of a file holding it.
+	ClientCert string
+	// ClientKey is the client private key, or the path of a file holding it.
+	ClientKey string
+	// ServerName overrides the host name the registry certificate is verified
+	// against.
+	ServerName string
+	// InsecureSkipVerify disables verification of the registry certificate.
+	InsecureSkipVerify bool
+}
+
+// NewTLSConfig builds the TLS configuration described by opts. It returns
+// nil when opts is empty, so that the default configuration is used.
+func NewTLSConfig(opts TLSOptions) (*tls.Config, error) {
+	if opts == (TLSOptions{}) {
+		return nil, nil
+	}
+
+	if opts.CACert != "" && opts.CACertFile != "" {
+		return nil, errors.New("only one of ca_cert and ca_cert_file may be set")
+	}
+	if (opts.ClientCert == "") != (opts.ClientKey == "") {
+		return nil, errors.New("client_cert and client_key must be set together")
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         opts.ServerName,
+		InsecureSkipVerify: opts.InsecureSkipVerify, //nolint:gosec // explicitly requested by the user
+	}
+
+	caCert := []byte(opts.CACert)
+	if opts.CACertFile != "" {
+		var err error
+		if caCert, err = os.ReadFile(opts.CACertFile); err != nil {
+			return nil, fmt.Errorf("could not read ca_cert_file: %w", err)
+		}
+	}
+	if len(caCert) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("the CA certificate does not contain any PEM encoded certificate")
+		}
+		config.RootCAs = pool
+	}
+
+	if opts.ClientCert != "" {
+		certPEM, err := pemOrFile(opts.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("could not read client_cert: %w", err)
+		}
+		keyPEM, err := pemOrFile(opts.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not read client_key: %w", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// pemOrFile returns value when it holds PEM data, or the contents of the file
+// it names otherwise.
+func pemOrFile(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
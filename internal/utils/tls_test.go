@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testClientCert returns a self-signed client certificate and key as PEM.
+func testClientCert(t *testing.T) (certPEM, keyPEM string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+// testTLSServer starts a TLS server that requires clientCertPEM and returns
+// it with its CA certificate as PEM.
+func testTLSServer(t *testing.T, clientCertPEM string) (*httptest.Server, string) {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM([]byte(clientCertPEM))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return server, string(caPEM)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	certPEM, keyPEM := testClientCert(t)
+	server, caPEM := testTLSServer(t, certPEM)
+
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	caFile := writeFile("ca.pem", caPEM)
+	certFile := writeFile("client.pem", certPEM)
+	keyFile := writeFile("client-key.pem", keyPEM)
+
+	tests := []struct {
+		name    string
+		opts    TLSOptions
+		wantErr bool
+	}{
+		{
+			name: "inline PEM",
+			opts: TLSOptions{CACert: caPEM, ClientCert: certPEM, ClientKey: keyPEM},
+		},
+		{
+			name: "files",
+			opts: TLSOptions{CACertFile: caFile, ClientCert: certFile, ClientKey: keyFile},
+		},
+		{
+			name: "server name",
+			opts: TLSOptions{CACert: caPEM, ClientCert: certPEM, ClientKey: keyPEM, ServerName: "example.com"},
+		},
+		{
+			name: "insecure skip verify",
+			opts: TLSOptions{InsecureSkipVerify: true, ClientCert: certPEM, ClientKey: keyPEM},
+		},
+		{
+			name:    "unknown CA",
+			opts:    TLSOptions{ClientCert: certPEM, ClientKey: keyPEM},
+			wantErr: true,
+		},
+		{
+			name:    "wrong server name",
+			opts:    TLSOptions{CACert: caPEM, ClientCert: certPEM, ClientKey: keyPEM, ServerName: "registry.internal"},
+			wantErr: true,
+		},
+		{
+			name:    "missing client certificate",
+			opts:    TLSOptions{CACert: caPEM},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := NewTLSConfig(tt.opts)
+			if err != nil {
+				t.Fatalf("NewTLSConfig() error = %v", err)
+			}
+
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+			resp, err := client.Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GET error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewTLSConfig_invalid(t *testing.T) {
+	certPEM, _ := testClientCert(t)
+
+	tests := []struct {
+		name string
+		opts TLSOptions
+	}{
+		{name: "both CA options", opts: TLSOptions{CACert: certPEM, CACertFile: "ca.pem"}},
+		{name: "certificate without key", opts: TLSOptions{ClientCert: certPEM}},
+		{name: "CA without certificates", opts: TLSOptions{CACert: "not a certificate"}},
+		{name: "missing CA file", opts: TLSOptions{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTLSConfig(tt.opts); err == nil {
+				t.Error("NewTLSConfig() error = nil, want an error")
+			}
+		})
+	}
+
+	if config, err := NewTLSConfig(TLSOptions{}); config != nil || err != nil {
+		t.Errorf("NewTLSConfig() = %v, %v, want nil, nil", config, err)
+	}
+}
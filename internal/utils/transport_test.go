@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHeaderTransport(t *testing.T) {
+	var tenant, requestID atomic.Value
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant.Store(r.Header.Get("target-sr-cluster"))
+		requestID.Store(r.Header.Get("X-Request-Id"))
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(registry.Close)
+
+	client := NewClient(registry.URL, &http.Client{Transport: &HeaderTransport{
+		Headers: map[string]string{"target-sr-cluster": "lsrc-123", "X-Request-Id": "terraform"},
+	}})
+	if _, err := client.ListSubjects(context.Background(), "", false); err != nil {
+		t.Fatalf("ListSubjects() error = %v", err)
+	}
+
+	if got := tenant.Load(); got != "lsrc-123" {
+		t.Errorf("target-sr-cluster = %q, want %q", got, "lsrc-123")
+	}
+	if got := requestID.Load(); got != "terraform" {
+		t.Errorf("X-Request-Id = %q, want %q", got, "terraform")
+	}
+}
+
+func TestNewProxyFunc(t *testing.T) {
+	// The proxy answers for the registry, which does not resolve on its own
+	var proxied atomic.Value
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Store(r.URL.String())
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(proxy.Close)
+
+	tests := []struct {
+		name        string
+		proxyURL    string
+		noProxy     string
+		wantProxied bool
+	}{
+		{name: "proxy", proxyURL: proxy.URL, wantProxied: true},
+		{name: "no proxy", proxyURL: proxy.URL, noProxy: "registry.invalid", wantProxied: false},
+		{name: "no proxy domain", proxyURL: proxy.URL, noProxy: ".invalid", wantProxied: false},
+		{name: "other no proxy", proxyURL: proxy.URL, noProxy: "example.com", wantProxied: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxied.Store("")
+			proxyFunc, err := NewProxyFunc(tt.proxyURL, tt.noProxy)
+			if err != nil {
+				t.Fatalf("NewProxyFunc() error = %v", err)
+			}
+
+			client := NewClient("http://registry.invalid", &http.Client{Transport: &http.Transport{Proxy: proxyFunc}})
+			_, err = client.ListSubjects(context.Background(), "", false)
+
+			if tt.wantProxied {
+				if err != nil {
+					t.Fatalf("ListSubjects() error = %v", err)
+				}
+				if got := proxied.Load(); got != "http://registry.invalid/subjects" {
+					t.Errorf("proxied request = %q, want %q", got, "http://registry.invalid/subjects")
+				}
+				return
+			}
+			if got := proxied.Load(); got != "" {
+				t.Errorf("proxied request = %q, want a direct request", got)
+			}
+		})
+	}
+}
+
+func TestNewProxyFunc_invalid(t *testing.T) {
+	for _, proxyURL := range []string{"proxy:3128", "://proxy"} {
+		if _, err := NewProxyFunc(proxyURL, ""); err == nil {
+			t.Errorf("NewProxyFunc(%q) error = nil, want an error", proxyURL)
+		}
+	}
+}